@@ -0,0 +1,199 @@
+package JSONDiff
+
+import (
+	"JSONParser/JSONParser"
+	"JSONParser/JSONPointer"
+	"encoding/json"
+	"math/big"
+	"strconv"
+)
+
+type ChangeType int
+
+const (
+	Added ChangeType = iota
+	Removed
+	Modified
+	// TypeChanged is a value replaced by one of another json type, like a number by a string
+	TypeChanged
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Modified:
+		return "modified"
+	default:
+		return "type changed"
+	}
+}
+
+// Change is a difference between the two documents at Path,
+// Old is nil for an added value and New is nil for a removed one.
+type Change struct {
+	Type     ChangeType
+	Path     JSONPointer.Pointer
+	Old, New interface{}
+}
+
+type ArrayMode int
+
+const (
+	// ArraysOrdered compares the elements with the same index
+	ArraysOrdered ArrayMode = iota
+	// ArraysAsSets matches the elements regardless of their position
+	ArraysAsSets
+)
+
+type Options struct {
+	Arrays ArrayMode
+	// IDField is the member identifying the objects of an array compared as a set,
+	// the objects with the same id are compared member by member.
+	// The elements without it are matched only by an equal element.
+	IDField string
+}
+
+// Diff returns the changes turning old into new, parents before children.
+// With ArraysAsSets the path of a removed element has its index in old
+// while the path of the other elements has their index in new.
+func Diff(old, new interface{}, options Options) []Change {
+	var changes []Change
+	diff(&changes, JSONPointer.Pointer{}, old, new, options)
+	return changes
+}
+
+func diff(changes *[]Change, path JSONPointer.Pointer, old, new interface{}, options Options) {
+	if JSONParser.Equal(old, new) {
+		return
+	}
+
+	oldType := TypeName(old)
+	if oldType != TypeName(new) {
+		*changes = append(*changes, Change{Type: TypeChanged, Path: path, Old: old, New: new})
+		return
+	}
+
+	switch oldType {
+	case "object":
+		diffObject(changes, path, old, new, options)
+	case "array":
+		if options.Arrays == ArraysAsSets {
+			diffSet(changes, path, old.([]interface{}), new.([]interface{}), options)
+		} else {
+			diffOrdered(changes, path, old.([]interface{}), new.([]interface{}), options)
+		}
+	default:
+		*changes = append(*changes, Change{Type: Modified, Path: path, Old: old, New: new})
+	}
+}
+
+func diffObject(changes *[]Change, path JSONPointer.Pointer, old, new interface{}, options Options) {
+	oldKeys, _ := JSONParser.ObjectKeys(old)
+	for _, k := range oldKeys {
+		oldValue, _ := JSONParser.Member(old, k)
+		newValue, ok := JSONParser.Member(new, k)
+		if !ok {
+			*changes = append(*changes, Change{Type: Removed, Path: path.Append(k), Old: oldValue})
+			continue
+		}
+		diff(changes, path.Append(k), oldValue, newValue, options)
+	}
+	newKeys, _ := JSONParser.ObjectKeys(new)
+	for _, k := range newKeys {
+		if _, ok := JSONParser.Member(old, k); !ok {
+			newValue, _ := JSONParser.Member(new, k)
+			*changes = append(*changes, Change{Type: Added, Path: path.Append(k), New: newValue})
+		}
+	}
+}
+
+func diffOrdered(changes *[]Change, path JSONPointer.Pointer, old, new []interface{}, options Options) {
+	for i := 0; i < max(len(old), len(new)); i++ {
+		elementPath := path.Append(strconv.Itoa(i))
+		switch {
+		case i >= len(new):
+			*changes = append(*changes, Change{Type: Removed, Path: elementPath, Old: old[i]})
+		case i >= len(old):
+			*changes = append(*changes, Change{Type: Added, Path: elementPath, New: new[i]})
+		default:
+			diff(changes, elementPath, old[i], new[i], options)
+		}
+	}
+}
+
+func diffSet(changes *[]Change, path JSONPointer.Pointer, old, new []interface{}, options Options) {
+	matched := make([]bool, len(old))
+
+	var added []int
+	for j, element := range new {
+		if options.IDField != "" {
+			if i, found := matchID(old, matched, element, options.IDField); found {
+				matched[i] = true
+				diff(changes, path.Append(strconv.Itoa(j)), old[i], element, options)
+				continue
+			}
+		}
+
+		found := false
+		for i := range old {
+			if !matched[i] && JSONParser.Equal(old[i], element) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			added = append(added, j)
+		}
+	}
+
+	for i, element := range old {
+		if !matched[i] {
+			*changes = append(*changes, Change{Type: Removed, Path: path.Append(strconv.Itoa(i)), Old: element})
+		}
+	}
+	for _, j := range added {
+		*changes = append(*changes, Change{Type: Added, Path: path.Append(strconv.Itoa(j)), New: new[j]})
+	}
+}
+
+// matchID returns the first old element not matched yet with an id equal to the id of element,
+// ids are compared with JSONParser.Equal so large integers kept as json.Number stay distinct
+func matchID(old []interface{}, matched []bool, element interface{}, field string) (int, bool) {
+	id, ok := JSONParser.Member(element, field)
+	if !ok {
+		return 0, false
+	}
+	for i, candidate := range old {
+		if matched[i] {
+			continue
+		}
+		if oldID, ok := JSONParser.Member(candidate, field); ok && JSONParser.Equal(oldID, id) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// TypeName returns the json type of a parsed value: object, array, string, number, boolean or null
+func TypeName(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}, *JSONParser.OrderedObject:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	case float64, int64, *big.Int, json.Number:
+		// NaN and the infinities of JSON5 are numbers too
+		return "number"
+	}
+	return "unknown"
+}
@@ -0,0 +1,161 @@
+package JSONDiff
+
+import (
+	"JSONParser/JSONParser"
+	"JSONParser/JSONScanner"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func parse(t *testing.T, input string) interface{} {
+	t.Helper()
+	value, err := JSONParser.ParseWithOptions([]byte(input), JSONParser.Options{OrderedObjects: true})
+	if err != nil {
+		t.Fatalf("%s: %v", input, err)
+	}
+	return value
+}
+
+// summary formats the changes as "type path old new" to compare them easily
+func summary(changes []Change) []string {
+	var lines []string
+	for _, change := range changes {
+		old, _ := JSONParser.Stringify(change.Old)
+		new, _ := JSONParser.Stringify(change.New)
+		lines = append(lines, fmt.Sprintf("%s %s %s %s", change.Type, change.Path, old, new))
+	}
+	return lines
+}
+
+func TestDiff(t *testing.T) {
+	old := parse(t, `{"name": "a", "n": 1, "tags": ["x", "y", "z"], "o": {"k": true}, "gone": null}`)
+	new := parse(t, `{"name": "b", "n": "1", "tags": ["x", "w"], "o": {"k": true, "l": [1]}, "new": {}}`)
+
+	expected := []string{
+		`modified /name "a" "b"`,
+		`type changed /n 1 "1"`,
+		`modified /tags/1 "y" "w"`,
+		`removed /tags/2 "z" null`,
+		`added /o/l null [1]`,
+		`removed /gone null null`,
+		`added /new null {}`,
+	}
+	if changes := summary(Diff(old, new, Options{})); !reflect.DeepEqual(expected, changes) {
+		t.Errorf("expected\n%v\ngot\n%v", expected, changes)
+	}
+
+	if changes := Diff(old, parse(t, `{"name": "a", "n": 1.0, "tags": ["x", "y", "z"], "gone": null, "o": {"k": true}}`), Options{}); len(changes) != 0 {
+		t.Errorf("expected no changes got %v", summary(changes))
+	}
+
+	if changes := summary(Diff(old, []interface{}{}, Options{})); !reflect.DeepEqual(changes, []string{
+		`type changed  {"name":"a","n":1,"tags":["x","y","z"],"o":{"k":true},"gone":null} []`,
+	}) {
+		t.Errorf("unexpected root change %v", changes)
+	}
+}
+
+func TestDiffArraysAsSets(t *testing.T) {
+	old := parse(t, `{"users": [
+		{"id": 1, "name": "ann"},
+		{"id": 2, "name": "bob"},
+		{"id": 3, "name": "cid"},
+		"plain", "plain", 5
+	]}`)
+	new := parse(t, `{"users": [
+		{"id": 3, "name": "cid"},
+		{"id": 4, "name": "dan"},
+		5,
+		{"id": 1, "name": "ann", "admin": true},
+		"plain"
+	]}`)
+
+	expected := []string{
+		`added /users/3/admin null true`,
+		`removed /users/1 {"id":2,"name":"bob"} null`,
+		`removed /users/4 "plain" null`,
+		`added /users/1 null {"id":4,"name":"dan"}`,
+	}
+	if changes := summary(Diff(old, new, Options{Arrays: ArraysAsSets, IDField: "id"})); !reflect.DeepEqual(expected, changes) {
+		t.Errorf("expected\n%v\ngot\n%v", expected, changes)
+	}
+
+	expected = []string{
+		`removed /users/0 {"id":1,"name":"ann"} null`,
+		`removed /users/1 {"id":2,"name":"bob"} null`,
+		`removed /users/4 "plain" null`,
+		`added /users/1 null {"id":4,"name":"dan"}`,
+		`added /users/3 null {"id":1,"name":"ann","admin":true}`,
+	}
+	if changes := summary(Diff(old, new, Options{Arrays: ArraysAsSets})); !reflect.DeepEqual(expected, changes) {
+		t.Errorf("expected\n%v\ngot\n%v", expected, changes)
+	}
+
+	if changes := Diff(parse(t, `[1, 2, 2]`), parse(t, `[2, 1, 2]`), Options{Arrays: ArraysAsSets}); len(changes) != 0 {
+		t.Errorf("expected no changes got %v", summary(changes))
+	}
+}
+
+func TestDiffArraysAsSetsMatchesEqualIDs(t *testing.T) {
+	old := parse(t, `[{"id": {"a": 1, "b": 2}, "v": 1}, {"id": 1.0, "v": 1}]`)
+	new := []interface{}{
+		map[string]interface{}{"id": json.Number("1.0"), "v": 2.0},
+		parse(t, `{"id": {"b": 2, "a": 1e0}, "v": 2}`),
+	}
+
+	expected := []string{
+		`modified /0/v 1 2`,
+		`modified /1/v 1 2`,
+	}
+	if changes := summary(Diff(old, new, Options{Arrays: ArraysAsSets, IDField: "id"})); !reflect.DeepEqual(expected, changes) {
+		t.Errorf("expected\n%v\ngot\n%v", expected, changes)
+	}
+}
+
+func TestDiffArraysAsSetsLargeIDs(t *testing.T) {
+	options := JSONParser.Options{OrderedObjects: true, Numbers: JSONScanner.NumbersAsLiteral}
+	old, err := JSONParser.ParseWithOptions([]byte(`[{"id": 12345678901234567890, "v": 1}, {"id": 12345678901234567891, "v": 2}]`), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	new, err := JSONParser.ParseWithOptions([]byte(`[{"id": 12345678901234567891, "v": 2}, {"id": 12345678901234567890, "v": 1}]`), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if changes := Diff(old, new, Options{Arrays: ArraysAsSets, IDField: "id"}); len(changes) != 0 {
+		t.Errorf("expected no changes got %v", summary(changes))
+	}
+
+	// elements sharing an id are matched in order
+	old = parse(t, `[{"id": 1, "v": 1}, {"id": 1, "v": 2}]`)
+	new = parse(t, `[{"id": 1, "v": 1}, {"id": 1, "v": 3}]`)
+	expected := []string{`modified /1/v 2 3`}
+	if changes := summary(Diff(old, new, Options{Arrays: ArraysAsSets, IDField: "id"})); !reflect.DeepEqual(expected, changes) {
+		t.Errorf("expected\n%v\ngot\n%v", expected, changes)
+	}
+}
+
+func TestTypeName(t *testing.T) {
+	document := parse(t, `[{}, [], "s", 1, true, null]`)
+	expected := []string{"object", "array", "string", "number", "boolean", "null"}
+	for i, value := range document.([]interface{}) {
+		if name := TypeName(value); name != expected[i] {
+			t.Errorf("expected %s got %s", expected[i], name)
+		}
+	}
+
+	for _, value := range []interface{}{math.NaN(), math.Inf(1), math.Inf(-1), int64(1), big.NewInt(1), json.Number("1e20000")} {
+		if name := TypeName(value); name != "number" {
+			t.Errorf("%v: expected number got %s", value, name)
+		}
+	}
+
+	if changes := Diff(math.NaN(), 1.0, Options{}); len(changes) != 1 || changes[0].Type != Modified {
+		t.Errorf("expected NaN modified to 1 got %+v", changes)
+	}
+}
@@ -0,0 +1,45 @@
+package JSONDiff
+
+import (
+	"JSONParser/Util"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// Render writes the changes in a unified diff like format, one hunk per change:
+//
+//	@@ /store/bicycle/price modified @@
+//	-399
+//	+349
+//
+// The values are printed by a Util.Printer, each of their lines prefixed by - or +.
+func Render(w io.Writer, changes []Change) error {
+	var buf bytes.Buffer
+	for _, change := range changes {
+		path := change.Path.String()
+		if path == "" {
+			path = "(root)"
+		}
+
+		description := change.Type.String()
+		if change.Type == TypeChanged {
+			description = fmt.Sprintf("type changed from %s to %s", TypeName(change.Old), TypeName(change.New))
+		}
+		fmt.Fprintf(&buf, "@@ %s %s @@\n", path, description)
+
+		if change.Type != Added {
+			if err := Util.NewPrinter(&buf, Util.PrintOptions{Prefix: "-"}).Print(change.Old); err != nil {
+				return err
+			}
+		}
+		if change.Type != Removed {
+			if err := Util.NewPrinter(&buf, Util.PrintOptions{Prefix: "+"}).Print(change.New); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
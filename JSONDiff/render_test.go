@@ -0,0 +1,63 @@
+package JSONDiff
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	old := parse(t, `{"price": 399, "color": "red", "tags": [1]}`)
+	new := parse(t, `{"price": 349, "color": ["red", "blue"], "size": {"frame": "M", "wheel": 28}}`)
+
+	var output strings.Builder
+	if err := Render(&output, Diff(old, new, Options{})); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `@@ /price modified @@
+-399
++349
+@@ /color type changed from string to array @@
+-"red"
++[
++  "red",
++  "blue"
++]
+@@ /tags removed @@
+-[
+-  1
+-]
+@@ /size added @@
++{
++  "frame": "M",
++  "wheel": 28
++}
+`
+	if output.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, output.String())
+	}
+
+	output.Reset()
+	if err := Render(&output, Diff(float64(1), "1", Options{})); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "@@ (root) type changed from number to string @@\n-1\n+\"1\"\n"; output.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, output.String())
+	}
+}
+
+func TestRenderWriteError(t *testing.T) {
+	writeErr := errors.New("closed pipe")
+	if err := Render(errWriter{writeErr}, Diff(float64(1), float64(2), Options{})); !errors.Is(err, writeErr) {
+		t.Errorf("expected %v got %v", writeErr, err)
+	}
+}
+
+type errWriter struct {
+	err error
+}
+
+func (w errWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
@@ -0,0 +1,131 @@
+package JSONParser
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Canonicalize encodes a value returned by Parse with the JSON Canonicalization Scheme of RFC 8785,
+// the output is byte for byte the same for equal values so it can be hashed or signed.
+// The members are sorted by the UTF-16 code units of their keys, whatever the order of an *OrderedObject,
+// the numbers are written like the ECMAScript Number.prototype.toString of their float64 value
+// and the strings only escape the quote, the backslash and the control characters.
+// Invalid utf-8 and numbers out of the float64 range are errors.
+func Canonicalize(value interface{}) ([]byte, error) {
+	return appendCanonical(nil, value)
+}
+
+func appendCanonical(buf []byte, value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(buf, "null"...), nil
+	case bool:
+		return strconv.AppendBool(buf, v), nil
+	case float64:
+		return appendCanonicalFloat(buf, v)
+	case int64:
+		return appendCanonicalFloat(buf, float64(v))
+	case *big.Int:
+		return appendCanonicalNumber(buf, v.String())
+	case json.Number:
+		return appendCanonicalNumber(buf, string(v))
+	case string:
+		return appendCanonicalString(buf, v)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		return appendCanonicalMembers(buf, keys, func(k string) interface{} {
+			return v[k]
+		})
+	case *OrderedObject:
+		keys := append([]string{}, v.Keys()...)
+		return appendCanonicalMembers(buf, keys, func(k string) interface{} {
+			member, _ := v.Get(k)
+			return member
+		})
+	case []interface{}:
+		var err error
+		buf = append(buf, '[')
+		for i, element := range v {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			if buf, err = appendCanonical(buf, element); err != nil {
+				return nil, err
+			}
+		}
+		return append(buf, ']'), nil
+	default:
+		return nil, fmt.Errorf("json: unsupported type %T", value)
+	}
+}
+
+// appendCanonicalMembers sorts the keys in place by their UTF-16 code units before writing the members
+func appendCanonicalMembers(buf []byte, keys []string, get func(string) interface{}) ([]byte, error) {
+	units := make(map[string][]uint16, len(keys))
+	for _, k := range keys {
+		units[k] = utf16.Encode([]rune(k))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return lessUTF16(units[keys[i]], units[keys[j]])
+	})
+
+	var err error
+	buf = append(buf, '{')
+	for i, k := range keys {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		if buf, err = appendCanonicalString(buf, k); err != nil {
+			return nil, err
+		}
+		buf = append(buf, ':')
+		if buf, err = appendCanonical(buf, get(k)); err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, '}'), nil
+}
+
+func lessUTF16(a, b []uint16) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// appendCanonicalNumber writes the float64 nearest to the text of a number
+func appendCanonicalNumber(buf []byte, text string) ([]byte, error) {
+	if !validNumber(text) {
+		return nil, fmt.Errorf("json: invalid number literal %q", text)
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, fmt.Errorf("json: number %s out of the float64 range", text)
+	}
+	return appendCanonicalFloat(buf, f)
+}
+
+// appendCanonicalFloat is appendFloat without the sign of -0, which ECMAScript writes as 0
+func appendCanonicalFloat(buf []byte, f float64) ([]byte, error) {
+	if f == 0 {
+		return append(buf, '0'), nil
+	}
+	return appendFloat(buf, f)
+}
+
+func appendCanonicalString(buf []byte, s string) ([]byte, error) {
+	if !utf8.ValidString(s) {
+		return nil, fmt.Errorf("json: invalid utf-8 in string %q", s)
+	}
+	return appendString(buf, s), nil
+}
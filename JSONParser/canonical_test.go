@@ -0,0 +1,130 @@
+package JSONParser
+
+import (
+	"JSONParser/JSONScanner"
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// the examples of RFC 8785 section 3.2.2 and 3.2.3
+func TestCanonicalize(t *testing.T) {
+	cases := []struct {
+		input, expected string
+	}{
+		{`{
+  "numbers": [333333333.33333329, 1E30, 4.50,
+              2e-3, 0.000000000000000000000000001],
+  "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+  "literals": [null, true, false]
+}`, `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`},
+		{`{
+  "\u20ac": "Euro Sign",
+  "\r": "Carriage Return",
+  "\ufb33": "Hebrew Letter Dalet With Dagesh",
+  "1": "One",
+  "\ud83d\ude00": "Emoji: Grinning Face",
+  "\u0080": "Control",
+  "\u00f6": "Latin Small Letter O With Diaeresis"
+}`, "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"ö\":\"Latin Small Letter O With Diaeresis\"," +
+			"\"€\":\"Euro Sign\",\"😀\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}"},
+	}
+
+	for _, c := range cases {
+		for _, options := range []Options{{}, {OrderedObjects: true}} {
+			parsed, err := ParseWithOptions([]byte(c.input), options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			output, err := Canonicalize(parsed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(output) != c.expected {
+				t.Errorf("expected\n%s\ngot\n%s", c.expected, output)
+			}
+		}
+	}
+}
+
+// the IEEE 754 values of RFC 8785 appendix B
+func TestCanonicalNumbers(t *testing.T) {
+	cases := []struct {
+		bits     uint64
+		expected string
+	}{
+		{0x0000000000000000, "0"},
+		{0x8000000000000000, "0"},
+		{0x0000000000000001, "5e-324"},
+		{0x8000000000000001, "-5e-324"},
+		{0x7fefffffffffffff, "1.7976931348623157e+308"},
+		{0xffefffffffffffff, "-1.7976931348623157e+308"},
+		{0x4340000000000000, "9007199254740992"},
+		{0xc340000000000000, "-9007199254740992"},
+		{0x4430000000000000, "295147905179352830000"},
+		{0x44b52d02c7e14af5, "9.999999999999997e+22"},
+		{0x44b52d02c7e14af6, "1e+23"},
+		{0x44b52d02c7e14af7, "1.0000000000000001e+23"},
+		{0x444b1ae4d6e2ef4e, "999999999999999700000"},
+		{0x444b1ae4d6e2ef4f, "999999999999999900000"},
+		{0x444b1ae4d6e2ef50, "1e+21"},
+		{0x3eb0c6f7a0b5ed8c, "9.999999999999997e-7"},
+		{0x3eb0c6f7a0b5ed8d, "0.000001"},
+		{0x41b3de4355555553, "333333333.3333332"},
+		{0x41b3de4355555554, "333333333.33333325"},
+		{0x41b3de4355555555, "333333333.3333333"},
+		{0x41b3de4355555556, "333333333.3333334"},
+		{0x41b3de4355555557, "333333333.33333343"},
+		{0xbecbf647612f3696, "-0.0000033333333333333333"},
+		{0x43143ff3c1cb0959, "1424953923781206.2"},
+	}
+
+	for _, c := range cases {
+		output, err := Canonicalize(math.Float64frombits(c.bits))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(output) != c.expected {
+			t.Errorf("%#016x: expected %s got %s", c.bits, c.expected, output)
+		}
+	}
+
+	for _, invalid := range []uint64{0x7fffffffffffffff, 0x7ff0000000000000} {
+		if _, err := Canonicalize(math.Float64frombits(invalid)); err == nil {
+			t.Errorf("%#016x: expected an error", invalid)
+		}
+	}
+}
+
+func TestCanonicalizeNumberModes(t *testing.T) {
+	input := []byte(`[1.50, -0, 1E2, 9007199254740993, 100000000000000000000000]`)
+	expected := `[1.5,0,100,9007199254740992,1e+23]`
+
+	for _, mode := range []JSONScanner.NumberMode{JSONScanner.NumbersAsFloat64, JSONScanner.NumbersAsLiteral, JSONScanner.NumbersAsInt64} {
+		parsed, err := ParseWithOptions(input, Options{Numbers: mode})
+		if err != nil {
+			t.Fatal(err)
+		}
+		output, err := Canonicalize(parsed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(output) != expected {
+			t.Errorf("mode %d: expected %s got %s", mode, expected, output)
+		}
+	}
+
+	invalid := []interface{}{
+		json.Number("1e400"),
+		json.Number("1;"),
+		"\xff",
+		map[string]interface{}{"\xff": 1.0},
+		new(big.Float),
+	}
+	for _, value := range invalid {
+		if _, err := Canonicalize(value); err == nil {
+			t.Errorf("%v: expected an error", value)
+		}
+	}
+}
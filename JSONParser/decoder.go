@@ -0,0 +1,53 @@
+package JSONParser
+
+import (
+	"JSONParser/JSONScanner"
+	"io"
+)
+
+// Decoder reads successive top level values from one input, like {"a":1}{"b":2} [3],
+// the values can be separated by any whitespace or nothing at all.
+// Decode stops reading at the end of each value so on a network stream a value is returned
+// as soon as it is complete, More has to wait for the start of the next value or the end of the stream.
+// The Options.Limits MaxInputSize and MaxTokens count the whole stream, not each value.
+type Decoder struct {
+	parser *JSONParser
+	offset int
+	err    error
+}
+
+func NewDecoder(reader io.Reader, options Options) *Decoder {
+	return &Decoder{parser: newParser(JSONScanner.NewJSONLexer(reader), options)}
+}
+
+// More reports if there is another value to decode
+func (decoder *Decoder) More() bool {
+	if decoder.err == nil && decoder.parser.lookahead == nil {
+		decoder.err = decoder.parser.next()
+	}
+	return decoder.err == nil && decoder.parser.lookahead.Type != JSONScanner.EOF
+}
+
+// Decode returns the next value, io.EOF when the input has no more values.
+// After an error the following calls return the same error.
+func (decoder *Decoder) Decode() (interface{}, error) {
+	if !decoder.More() {
+		if decoder.err != nil {
+			return nil, decoder.err
+		}
+		return nil, io.EOF
+	}
+
+	decoder.offset = decoder.parser.lookahead.Offset
+	value, err := decoder.parser.parseValue()
+	if err != nil {
+		decoder.err = err
+		return nil, err
+	}
+	return value, nil
+}
+
+// Offset returns the byte offset where the value returned by the last Decode starts
+func (decoder *Decoder) Offset() int {
+	return decoder.offset
+}
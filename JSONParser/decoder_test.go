@@ -0,0 +1,123 @@
+package JSONParser
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestDecoder(t *testing.T) {
+	input := `{"a":1}{"b":2} [3]
+	"four"5 true null`
+
+	expected := []interface{}{
+		map[string]interface{}{"a": float64(1)},
+		map[string]interface{}{"b": float64(2)},
+		[]interface{}{float64(3)},
+		"four",
+		float64(5),
+		true,
+		nil,
+	}
+	offsets := []int{0, 7, 15, 20, 26, 28, 33}
+
+	decoder := NewDecoder(iotest.OneByteReader(strings.NewReader(input)), Options{})
+	for i := range expected {
+		if !decoder.More() {
+			t.Fatalf("expected value %d", i)
+		}
+		value, err := decoder.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(expected[i], value) {
+			t.Errorf("expected %v got %v", expected[i], value)
+		}
+		if decoder.Offset() != offsets[i] {
+			t.Errorf("value %d: expected offset %d got %d", i, offsets[i], decoder.Offset())
+		}
+	}
+
+	if decoder.More() {
+		t.Errorf("expected no more values")
+	}
+	if _, err := decoder.Decode(); err != io.EOF {
+		t.Errorf("expected io.EOF got %v", err)
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	decoder := NewDecoder(strings.NewReader(`[1] {"a" 2} [3]`), Options{})
+
+	if _, err := decoder.Decode(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := decoder.Decode()
+	var parseError *ParseError
+	if !errors.As(err, &parseError) || parseError.Offset != 9 {
+		t.Fatalf("expected a ParseError at offset 9 got %v", err)
+	}
+	if decoder.More() {
+		t.Errorf("expected the decoder to stop after an error")
+	}
+	if _, again := decoder.Decode(); again != err {
+		t.Errorf("expected the same error got %v", again)
+	}
+
+	truncated := NewDecoder(strings.NewReader(`[1] "\`), Options{})
+	if _, err := truncated.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := truncated.Decode(); errors.Is(err, io.EOF) || !errors.As(err, &parseError) {
+		t.Errorf("expected a ParseError for the truncated string got %v", err)
+	}
+
+	empty := NewDecoder(strings.NewReader("  \n "), Options{})
+	if empty.More() {
+		t.Errorf("expected no values")
+	}
+	if _, err := empty.Decode(); err != io.EOF {
+		t.Errorf("expected io.EOF got %v", err)
+	}
+}
+
+func TestDecoderDoesNotReadAhead(t *testing.T) {
+	reader, writer := io.Pipe()
+	decoder := NewDecoder(reader, Options{})
+
+	values := make(chan interface{})
+	go func() {
+		defer close(values)
+		for {
+			value, err := decoder.Decode()
+			if err != nil {
+				return
+			}
+			values <- value
+		}
+	}()
+
+	// every value is returned before the next one is written
+	for _, input := range []string{`{"a":1}`, ` [2]`, "\n3 ", `"four"`} {
+		if _, err := writer.Write([]byte(input)); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case value := <-values:
+			if value == nil {
+				t.Fatalf("%s: unexpected end of values", input)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: the value was not returned before the next one started", input)
+		}
+	}
+	writer.Close()
+	if _, more := <-values; more {
+		t.Errorf("expected no more values")
+	}
+}
@@ -0,0 +1,89 @@
+package JSONParser
+
+import (
+	"JSONParser/JSONScanner"
+	"fmt"
+)
+
+// ParseError reports where and why the json is invalid.
+// Token is nil when the scanner failed before producing a token,
+// in that case Err holds the error returned by the scanner.
+type ParseError struct {
+	Line, Column int
+	// Offset is the byte offset of the error in the input
+	Offset int
+	Token  *JSONScanner.Token
+	// Expected holds the token types that would have been valid instead of Token
+	Expected []int
+	Msg      string
+	Err      error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s, Line %d, col %d", e.Msg, e.Line, e.Column)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ExpectedNames returns the readable names of the expected token types
+func (e *ParseError) ExpectedNames() []string {
+	names := make([]string, len(e.Expected))
+	for i, tType := range e.Expected {
+		names[i] = name(tType)
+	}
+	return names
+}
+
+// unexpected reports the lookahead token as invalid in the current context
+func (parser *JSONParser) unexpected(context string, expected ...int) *ParseError {
+	token := parser.lookahead
+	return &ParseError{
+		Line:     token.Line,
+		Column:   token.Column,
+		Offset:   token.Offset,
+		Token:    token,
+		Expected: expected,
+		Msg:      fmt.Sprintf("invalid token \"%v\" %s", token.Value, context),
+	}
+}
+
+// scannerError positions an error returned by the scanner at the last character it consumed
+func (parser *JSONParser) scannerError(err error) *ParseError {
+	return &ParseError{
+		Line:   parser.lexer.Line,
+		Column: parser.lexer.Column,
+		Offset: max(parser.lexer.Offset()-1, 0),
+		Msg:    err.Error(),
+		Err:    err,
+	}
+}
+
+// DuplicateKeyError reports a key repeated in the same object when Options.DuplicateKeys is DuplicateKeysError
+type DuplicateKeyError struct {
+	Key              string
+	First, Duplicate *JSONScanner.Token
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("duplicate key \"%s\" first defined at Line %d, col %d", e.Key, e.First.Line, e.First.Column)
+}
+
+// LimitError reports an input exceeding Options.MaxDepth or one of the Options.Limits,
+// Limit is the name of the field like MaxDepth or MaxStringLength
+type LimitError = JSONScanner.LimitError
+
+// limitExceeded reports the lookahead token as going over a limit
+func (parser *JSONParser) limitExceeded(limit string, max int) *ParseError {
+	token := parser.lookahead
+	err := &LimitError{Limit: limit, Max: max}
+	return &ParseError{
+		Line:   token.Line,
+		Column: token.Column,
+		Offset: token.Offset,
+		Token:  token,
+		Msg:    err.Error(),
+		Err:    err,
+	}
+}
@@ -0,0 +1,85 @@
+package JSONParser
+
+import (
+	"JSONParser/JSONScanner"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseErrorPosition(t *testing.T) {
+	cases := []struct {
+		input    string
+		line     int
+		column   int
+		offset   int
+		token    interface{}
+		expected []int
+	}{
+		{`{"a" 1}`, 1, 6, 5, float64(1), []int{JSONScanner.Colon}},
+		{"[1,\n  2", 2, 3, 7, "EOF", []int{JSONScanner.Comma, JSONScanner.RightSquareBracket}},
+		{`{"a": 1,}`, 1, 9, 8, "}", []int{JSONScanner.String}},
+		{`{"κλειδί": 1 2}`, 1, 14, 19, float64(2), []int{JSONScanner.Comma, JSONScanner.RightBracket}},
+		{`[1] x`, 1, 5, 4, nil, nil},
+		{`{"a": }`, 1, 7, 6, "}", valueTypes},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			_, err := Parse([]byte(c.input))
+
+			var parseError *ParseError
+			if !errors.As(err, &parseError) {
+				t.Fatalf("expected a ParseError got %v", err)
+			}
+			if parseError.Line != c.line || parseError.Column != c.column || parseError.Offset != c.offset {
+				t.Errorf("expected Line %d, col %d, offset %d got Line %d, col %d, offset %d",
+					c.line, c.column, c.offset, parseError.Line, parseError.Column, parseError.Offset)
+			}
+			if c.token != nil && (parseError.Token == nil || parseError.Token.Value != c.token) {
+				t.Errorf("expected token %v got %v", c.token, parseError.Token)
+			}
+			if c.expected != nil && !reflect.DeepEqual(c.expected, parseError.Expected) {
+				t.Errorf("expected %v got %v", c.expected, parseError.ExpectedNames())
+			}
+		})
+	}
+}
+
+func TestParseErrorFromScanner(t *testing.T) {
+	_, err := Parse([]byte("[\"tab\tcharacter\"]"))
+
+	var parseError *ParseError
+	if !errors.As(err, &parseError) {
+		t.Fatalf("expected a ParseError got %v", err)
+	}
+	if parseError.Token != nil || parseError.Err == nil {
+		t.Errorf("expected the scanner error got %#v", parseError)
+	}
+	if parseError.Line != 1 || parseError.Column != 6 || parseError.Offset != 5 {
+		t.Errorf("expected Line 1, col 6 got Line %d, col %d", parseError.Line, parseError.Column)
+	}
+}
+
+func TestParseErrorMessages(t *testing.T) {
+	for i := range make([]int, 33) {
+		filename := fmt.Sprintf("../tests/test/fail%d.json", i+1)
+		input, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = Parse(input)
+		var parseError *ParseError
+		if !errors.As(err, &parseError) {
+			t.Errorf("%s: expected a ParseError got %v", filename, err)
+			continue
+		}
+		if strings.Contains(parseError.Error(), "\n") {
+			t.Errorf("%s: message spans multiple lines %q", filename, parseError.Error())
+		}
+	}
+}
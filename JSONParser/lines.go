@@ -0,0 +1,88 @@
+package JSONParser
+
+import (
+	"JSONParser/JSONScanner"
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// Record is a value read from one line of a json lines input
+type Record struct {
+	Line  int
+	Value interface{}
+	// Err is the error of this line only, the following lines are still read
+	Err error
+}
+
+// LineReader reads json lines (ndjson), one value per line, reusing a single lexer for all of them.
+// Blank lines are skipped and the errors keep the line number of the whole input.
+// The Options.Limits apply to each line on its own.
+type LineReader struct {
+	reader  *bufio.Reader
+	lexer   *JSONScanner.JSONLexer
+	options Options
+	line    int
+	buf     []byte
+	record  Record
+	err     error
+}
+
+func NewLineReader(reader io.Reader, options Options) *LineReader {
+	return &LineReader{
+		reader:  bufio.NewReader(reader),
+		lexer:   &JSONScanner.JSONLexer{},
+		options: options,
+	}
+}
+
+// Next parses the next non blank line, it returns false at the end of the input or on a read error
+func (r *LineReader) Next() bool {
+	for r.err == nil {
+		line, err := r.readLine()
+		if err != nil && (err != io.EOF || len(line) == 0) {
+			if err != io.EOF {
+				r.err = err
+			}
+			return false
+		}
+		r.line++
+
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		r.lexer.Line = r.line
+		r.lexer.Column = 0
+		r.lexer.ReadJson(line)
+		value, parseErr := parse(r.lexer, r.options)
+		r.record = Record{Line: r.line, Value: value, Err: parseErr}
+		return true
+	}
+	return false
+}
+
+// Record returns the record read by the last call to Next
+func (r *LineReader) Record() Record {
+	return r.record
+}
+
+// Err returns the read error that stopped Next, errors of single lines are reported in their Record
+func (r *LineReader) Err() error {
+	return r.err
+}
+
+// readLine returns the next line without its line ending, the slice is reused by the following call
+func (r *LineReader) readLine() ([]byte, error) {
+	r.buf = r.buf[:0]
+	for {
+		chunk, err := r.reader.ReadSlice('\n')
+		r.buf = append(r.buf, chunk...)
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		line := bytes.TrimSuffix(r.buf, []byte("\n"))
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		return line, err
+	}
+}
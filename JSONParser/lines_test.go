@@ -0,0 +1,98 @@
+package JSONParser
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestLineReader(t *testing.T) {
+	input := "{\"level\": \"info\", \"msg\": \"started\"}\r\n" +
+		"\n" +
+		"{\"level\": \"error\" \"msg\": \"missing comma\"}\n" +
+		"   \n" +
+		"[1, 2]\n" +
+		"{\"level\": \"info\",\n" +
+		"\"no newline at the end\""
+
+	reader := NewLineReader(strings.NewReader(input), Options{})
+
+	type result struct {
+		line  int
+		value interface{}
+		err   bool
+	}
+	var results []result
+	for reader.Next() {
+		record := reader.Record()
+		results = append(results, result{record.Line, record.Value, record.Err != nil})
+
+		var parseError *ParseError
+		if record.Err != nil && (!errors.As(record.Err, &parseError) || parseError.Line != record.Line) {
+			t.Errorf("expected a ParseError on Line %d got %v", record.Line, record.Err)
+		}
+	}
+	if reader.Err() != nil {
+		t.Fatal(reader.Err())
+	}
+
+	expected := []result{
+		{1, map[string]interface{}{"level": "info", "msg": "started"}, false},
+		{3, nil, true},
+		{5, []interface{}{float64(1), float64(2)}, false},
+		{6, nil, true},
+		{7, "no newline at the end", false},
+	}
+	if !reflect.DeepEqual(expected, results) {
+		t.Errorf("expected %v got %v", expected, results)
+	}
+}
+
+func TestLineReaderLongLines(t *testing.T) {
+	long := strings.Repeat("x", 10000)
+	input := `"` + long + `"` + "\n" + `{"short": true}`
+
+	reader := NewLineReader(iotest.HalfReader(strings.NewReader(input)), Options{OrderedObjects: true})
+
+	if !reader.Next() || reader.Record().Value != long {
+		t.Fatalf("expected the long line got %v", reader.Record())
+	}
+	if !reader.Next() {
+		t.Fatalf("expected a second line")
+	}
+	if v, _ := reader.Record().Value.(*OrderedObject).Get("short"); v != true {
+		t.Errorf("unexpected second record %v", reader.Record())
+	}
+	if reader.Next() {
+		t.Errorf("unexpected third record %v", reader.Record())
+	}
+}
+
+func TestLineReaderReadError(t *testing.T) {
+	readErr := errors.New("disk failure")
+	reader := NewLineReader(iotest.ErrReader(readErr), Options{})
+
+	if reader.Next() {
+		t.Errorf("unexpected record %v", reader.Record())
+	}
+	if !errors.Is(reader.Err(), readErr) {
+		t.Errorf("expected %v got %v", readErr, reader.Err())
+	}
+}
+
+func BenchmarkLineReader(b *testing.B) {
+	input := strings.Repeat(`{"postId": 1, "id": 1, "name": "id labore ex et quam laborum", "email": "Eliseo@gardner.biz"}`+"\n", 1000)
+
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		reader := NewLineReader(strings.NewReader(input), Options{})
+		for reader.Next() {
+			if reader.Record().Err != nil {
+				b.Fatal(reader.Record().Err)
+			}
+		}
+	}
+}
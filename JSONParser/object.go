@@ -0,0 +1,88 @@
+package JSONParser
+
+import "sort"
+
+// OrderedObject is a json object that remembers the order of its members,
+// Parse returns it instead of map[string]interface{} when Options.OrderedObjects is set.
+type OrderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func NewOrderedObject() *OrderedObject {
+	return &OrderedObject{values: make(map[string]interface{})}
+}
+
+// Keys returns the keys in the order they were first set, the slice must not be modified
+func (object *OrderedObject) Keys() []string {
+	return object.keys
+}
+
+func (object *OrderedObject) Get(key string) (interface{}, bool) {
+	value, ok := object.values[key]
+	return value, ok
+}
+
+// Set adds the member at the end or replaces the value of an existing key keeping its position
+func (object *OrderedObject) Set(key string, value interface{}) {
+	if _, exists := object.values[key]; !exists {
+		object.keys = append(object.keys, key)
+	}
+	object.values[key] = value
+}
+
+func (object *OrderedObject) Delete(key string) {
+	if _, exists := object.values[key]; !exists {
+		return
+	}
+	delete(object.values, key)
+	for i, k := range object.keys {
+		if k == key {
+			object.keys = append(object.keys[:i], object.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+func (object *OrderedObject) Len() int {
+	return len(object.keys)
+}
+
+// Map returns the members in a map losing their order
+func (object *OrderedObject) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(object.values))
+	for k, v := range object.values {
+		m[k] = v
+	}
+	return m
+}
+
+// ObjectKeys returns the keys of an object, sorted for a map and in order for an *OrderedObject,
+// it returns false when v is not an object. The keys of an *OrderedObject must not be modified.
+func ObjectKeys(v interface{}) ([]string, bool) {
+	switch object := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(object))
+		for k := range object {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return keys, true
+	case *OrderedObject:
+		return object.Keys(), true
+	}
+	return nil, false
+}
+
+// Member returns the value of key in a map or an *OrderedObject,
+// it returns false when the key is missing or object is not an object
+func Member(object interface{}, key string) (interface{}, bool) {
+	switch o := object.(type) {
+	case map[string]interface{}:
+		v, ok := o[key]
+		return v, ok
+	case *OrderedObject:
+		return o.Get(key)
+	}
+	return nil, false
+}
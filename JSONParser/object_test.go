@@ -0,0 +1,127 @@
+package JSONParser
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseOrderedObjects(t *testing.T) {
+	input := []byte(`{"z": 1, "a": {"y": true, "b": null}, "m": [{"2": 2, "1": 1}], "a2": "x"}`)
+
+	parsed, err := ParseWithOptions(input, Options{OrderedObjects: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	object, ok := parsed.(*OrderedObject)
+	if !ok {
+		t.Fatalf("expected *OrderedObject got %T", parsed)
+	}
+	if !reflect.DeepEqual([]string{"z", "a", "m", "a2"}, object.Keys()) {
+		t.Errorf("unexpected key order %v", object.Keys())
+	}
+
+	nested, _ := object.Get("a")
+	if !reflect.DeepEqual([]string{"y", "b"}, nested.(*OrderedObject).Keys()) {
+		t.Errorf("unexpected key order %v", nested.(*OrderedObject).Keys())
+	}
+
+	output, err := Stringify(parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"z":1,"a":{"y":true,"b":null},"m":[{"2":2,"1":1}],"a2":"x"}`
+	if string(output) != expected {
+		t.Errorf("expected %s got %s", expected, output)
+	}
+}
+
+func TestOrderedObjectRoundTrip(t *testing.T) {
+	input, err := os.ReadFile("../tests/step4/valid2.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := ParseWithOptions(input, Options{OrderedObjects: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := Stringify(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 5; i++ {
+		parsed, err := ParseWithOptions(output, Options{OrderedObjects: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, err := Stringify(parsed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(again) != string(output) {
+			t.Fatalf("unstable output %s\n%s", output, again)
+		}
+	}
+}
+
+func TestOrderedObjectMethods(t *testing.T) {
+	object := NewOrderedObject()
+	object.Set("a", 1.0)
+	object.Set("b", 2.0)
+	object.Set("c", 3.0)
+	object.Set("a", 4.0)
+	object.Delete("b")
+	object.Delete("missing")
+
+	if !reflect.DeepEqual([]string{"a", "c"}, object.Keys()) || object.Len() != 2 {
+		t.Errorf("unexpected keys %v", object.Keys())
+	}
+	if v, ok := object.Get("a"); !ok || v != 4.0 {
+		t.Errorf("expected 4 got %v", v)
+	}
+	if _, ok := object.Get("b"); ok {
+		t.Errorf("deleted key still present")
+	}
+	if !reflect.DeepEqual(map[string]interface{}{"a": 4.0, "c": 3.0}, object.Map()) {
+		t.Errorf("unexpected map %v", object.Map())
+	}
+}
+
+func TestObjectKeysAndMember(t *testing.T) {
+	ordered := NewOrderedObject()
+	ordered.Set("b", 1.0)
+	ordered.Set("a", nil)
+
+	cases := []struct {
+		object interface{}
+		keys   []string
+		ok     bool
+	}{
+		{map[string]interface{}{"b": 1.0, "a": nil, "c": "x"}, []string{"a", "b", "c"}, true},
+		{ordered, []string{"b", "a"}, true},
+		{map[string]interface{}{}, []string{}, true},
+		{[]interface{}{"a"}, nil, false},
+		{"a", nil, false},
+	}
+	for _, c := range cases {
+		keys, ok := ObjectKeys(c.object)
+		if ok != c.ok || !reflect.DeepEqual(keys, c.keys) {
+			t.Errorf("%v: expected %v %v got %v %v", c.object, c.keys, c.ok, keys, ok)
+		}
+	}
+
+	if v, ok := Member(ordered, "a"); !ok || v != nil {
+		t.Errorf("expected a null member got %v %v", v, ok)
+	}
+	if v, ok := Member(map[string]interface{}{"b": 1.0}, "b"); !ok || v != 1.0 {
+		t.Errorf("expected 1 got %v %v", v, ok)
+	}
+	for _, object := range []interface{}{ordered, map[string]interface{}{}, []interface{}{"z"}, nil} {
+		if v, ok := Member(object, "z"); ok {
+			t.Errorf("%v: expected no member got %v", object, v)
+		}
+	}
+}
@@ -2,13 +2,57 @@ package JSONParser
 
 import (
 	"JSONParser/JSONScanner"
-	"fmt"
+	"io"
+	"math"
 )
 
+// DuplicateKeyPolicy decides what happens when an object repeats a key
+type DuplicateKeyPolicy int
+
+const (
+	// DuplicateKeysKeepLast overwrites the earlier members like the native json package
+	DuplicateKeysKeepLast DuplicateKeyPolicy = iota
+	DuplicateKeysKeepFirst
+	// DuplicateKeysError fails with a ParseError wrapping a *DuplicateKeyError
+	DuplicateKeysError
+	// DuplicateKeysCollect stores all the values of a repeated key in an array
+	DuplicateKeysCollect
+)
+
+// Options changes how the json is parsed, the zero value parses strict json
+type Options struct {
+	// OrderedObjects returns the objects as *OrderedObject keeping the members in the input order
+	OrderedObjects bool
+	DuplicateKeys  DuplicateKeyPolicy
+	// Numbers selects the go type of the numbers, json.Number with JSONScanner.NumbersAsLiteral
+	// or int64 for the integers with JSONScanner.NumbersAsInt64, float64 by default
+	Numbers JSONScanner.NumberMode
+	// AllowComments accepts the // line and /* block */ comments of JSONC
+	AllowComments bool
+	// JSON5 parses the JSON5 superset: comments, identifier keys, trailing commas,
+	// single quoted and multi-line strings, hex, signed and dotted numbers, Infinity and NaN
+	JSON5 bool
+	// AllowTrailingCommas accepts a single comma before the closing } or ]
+	AllowTrailingCommas bool
+	// OnTrailingComma is called with every accepted trailing comma,
+	// its Offset can be used to strip it from the input
+	OnTrailingComma func(comma *JSONScanner.Token)
+	// MaxDepth is the deepest nesting of objects and arrays accepted, DefaultMaxDepth when 0
+	// and unlimited when negative, a deeper input fails with a ParseError wrapping a *LimitError
+	MaxDepth int
+	// Limits bounds the input size, the strings, the tokens and the members and elements of each object and array,
+	// the parser stops at the first limit exceeded with a ParseError wrapping a *LimitError
+	Limits JSONScanner.Limits
+}
+
+// DefaultMaxDepth is the nesting limit used when Options.MaxDepth is 0
+const DefaultMaxDepth = 10000
+
 type JSONParser struct {
 	lexer         *JSONScanner.JSONLexer
 	lookahead     *JSONScanner.Token
 	errorOccurred error
+	options       Options
 }
 
 func name(tType int) string {
@@ -31,176 +75,445 @@ func name(tType int) string {
 		return "["
 	case JSONScanner.RightSquareBracket:
 		return "]"
+	case JSONScanner.Minus:
+		return "-"
+	case JSONScanner.EOF:
+		return "end of json"
+	case JSONScanner.Comment:
+		return "comment"
+	case JSONScanner.Identifier:
+		return "identifier"
 	default:
 		return "unknown token type"
 	}
 }
 
-func (parser *JSONParser) match(tType int) (*JSONScanner.Token, error) {
-	var err error
-	var prev *JSONScanner.Token
-	var nextToken *JSONScanner.Token
-
-	if parser.lookahead.Type == tType {
+// next reads the following token into lookahead skipping the comments
+func (parser *JSONParser) next() error {
+	nextToken, err := parser.lexer.GetNextToken()
+	for err == nil && nextToken.Type == JSONScanner.Comment {
 		nextToken, err = parser.lexer.GetNextToken()
-	} else {
-		err = fmt.Errorf("type mismatch expected %v got \"%v\", Line %d, col %d", name(tType), parser.lookahead.Value, parser.lexer.Line, parser.lexer.Column)
 	}
-
 	if err != nil {
+		return parser.scannerError(err)
+	}
+	parser.lookahead = nextToken
+	return nil
+}
+
+// match consumes the lookahead if it is of type tType,
+// otherwise it reports the lookahead as invalid while looking for context
+func (parser *JSONParser) match(tType int, context string) (*JSONScanner.Token, error) {
+	if parser.lookahead.Type != tType {
+		return nil, parser.unexpected(context, tType)
+	}
+
+	prev := parser.lookahead
+	if err := parser.next(); err != nil {
 		return nil, err
 	}
 
-	prev = parser.lookahead
-	parser.lookahead = nextToken
+	return prev, nil
+}
 
+// matchEnd is match for a token that may end the top level value, when last is set
+// the following token is not read and lookahead stays nil until the caller reads it,
+// so a Decoder returns a value without waiting for the next one to arrive
+func (parser *JSONParser) matchEnd(tType int, context string, last bool) (*JSONScanner.Token, error) {
+	if !last {
+		return parser.match(tType, context)
+	}
+	if parser.lookahead.Type != tType {
+		return nil, parser.unexpected(context, tType)
+	}
+	prev := parser.lookahead
+	parser.lookahead = nil
 	return prev, nil
 }
 
 func Parse(jsonBytes []byte) (interface{}, error) {
-	parser := JSONParser{}
-	parser.lexer = &JSONScanner.JSONLexer{Column: 0, Line: 1}
-	parser.lexer.ReadJson(jsonBytes)
+	return ParseWithOptions(jsonBytes, Options{})
+}
 
-	nextT, err := parser.lexer.GetNextToken()
-	if err != nil {
+func ParseWithOptions(jsonBytes []byte, options Options) (interface{}, error) {
+	lexer := &JSONScanner.JSONLexer{Column: 0, Line: 1}
+	lexer.ReadJson(jsonBytes)
+	return parse(lexer, options)
+}
+
+// ParseReader parses the json read from reader without loading the whole input in memory
+func ParseReader(reader io.Reader) (interface{}, error) {
+	return ParseReaderWithOptions(reader, Options{})
+}
+
+func ParseReaderWithOptions(reader io.Reader, options Options) (interface{}, error) {
+	return parse(JSONScanner.NewJSONLexer(reader), options)
+}
+
+func newParser(lexer *JSONScanner.JSONLexer, options Options) *JSONParser {
+	lexer.Numbers = options.Numbers
+	lexer.AllowComments = options.AllowComments
+	lexer.JSON5 = options.JSON5
+	lexer.Limits = options.Limits
+	return &JSONParser{lexer: lexer, options: options}
+}
+
+func parse(lexer *JSONScanner.JSONLexer, options Options) (interface{}, error) {
+	parser := newParser(lexer, options)
+
+	if err := parser.next(); err != nil {
 		return nil, err
 	}
 
-	parser.lookahead = nextT
-
 	parsedJson, err := parser.parseValue()
 	if err != nil {
 		return nil, err
 	}
-	if parser.lookahead.Type == JSONScanner.EOF {
-		return parsedJson, nil
-	} else {
-		return nil, fmt.Errorf("invalid token \"%v\" unexpected end of json", parser.lookahead.Value)
+	if err := parser.next(); err != nil {
+		return nil, err
+	}
+	if parser.lookahead.Type != JSONScanner.EOF {
+		return nil, parser.unexpected("looking for the end of json", JSONScanner.EOF)
 	}
+	return parsedJson, nil
 }
 
-func (parser *JSONParser) parseValue() (interface{}, error) {
-	if parser.lookahead.Type == JSONScanner.LeftBracket {
-		return parser.parseObject()
-	} else if parser.lookahead.Type == JSONScanner.LeftSquareBracket {
-		return parser.parseArray()
-	} else if parser.lookahead.Type == JSONScanner.String {
-		val, err := parser.match(JSONScanner.String)
-		if err != nil {
-			return nil, err
+var valueTypes = []int{
+	JSONScanner.LeftBracket,
+	JSONScanner.LeftSquareBracket,
+	JSONScanner.String,
+	JSONScanner.Number,
+	JSONScanner.Literal,
+}
+
+// json5Literals are the identifiers JSON5 accepts as values
+var json5Literals = map[string]interface{}{
+	"true":     true,
+	"false":    false,
+	"null":     nil,
+	"Infinity": math.Inf(1),
+	"NaN":      math.NaN(),
+}
+
+// startsValue reports if the lookahead is the first token of a value
+func (parser *JSONParser) startsValue() bool {
+	switch parser.lookahead.Type {
+	case JSONScanner.Number, JSONScanner.String, JSONScanner.LeftSquareBracket, JSONScanner.LeftBracket, JSONScanner.Literal:
+		return true
+	case JSONScanner.Identifier:
+		return parser.options.JSON5
+	}
+	return false
+}
+
+// keyTypes are the token types accepted as object keys
+func (parser *JSONParser) keyTypes() []int {
+	if parser.options.JSON5 {
+		return []int{JSONScanner.String, JSONScanner.Identifier}
+	}
+	return []int{JSONScanner.String}
+}
+
+func (parser *JSONParser) startsKey() bool {
+	return parser.lookahead.Type == JSONScanner.String ||
+		(parser.options.JSON5 && parser.lookahead.Type == JSONScanner.Identifier)
+}
+
+// trailingComma reports if the comma just consumed is followed by the closing token and that is allowed
+func (parser *JSONParser) trailingComma(comma *JSONScanner.Token, closing int) bool {
+	if parser.lookahead.Type != closing || !(parser.options.AllowTrailingCommas || parser.options.JSON5) {
+		return false
+	}
+	if parser.options.OnTrailingComma != nil {
+		parser.options.OnTrailingComma(comma)
+	}
+	return true
+}
+
+// members holds the object being parsed and what the duplicate key policy needs to remember
+type members struct {
+	obj       interface{}
+	keys      map[string]*JSONScanner.Token
+	collected map[string]bool
+}
+
+func (m *members) get(key string) (interface{}, bool) {
+	switch obj := m.obj.(type) {
+	case *OrderedObject:
+		return obj.Get(key)
+	default:
+		val, ok := obj.(map[string]interface{})[key]
+		return val, ok
+	}
+}
+
+func (m *members) set(key string, val interface{}) {
+	switch obj := m.obj.(type) {
+	case *OrderedObject:
+		obj.Set(key, val)
+	default:
+		obj.(map[string]interface{})[key] = val
+	}
+}
+
+// addMember stores the member applying the duplicate key policy
+func (parser *JSONParser) addMember(m *members, key *JSONScanner.Token, val interface{}) error {
+	k := key.Value.(string)
+	existing, exists := m.get(k)
+
+	switch parser.options.DuplicateKeys {
+	case DuplicateKeysKeepFirst:
+		if exists {
+			return nil
 		}
-		return val.Value, nil
-	} else if parser.lookahead.Type == JSONScanner.Number {
-		val, err := parser.match(JSONScanner.Number)
-		if err != nil {
-			return nil, err
+	case DuplicateKeysError:
+		if exists {
+			duplicate := &DuplicateKeyError{Key: k, First: m.keys[k], Duplicate: key}
+			return &ParseError{
+				Line:   key.Line,
+				Column: key.Column,
+				Offset: key.Offset,
+				Token:  key,
+				Msg:    duplicate.Error(),
+				Err:    duplicate,
+			}
 		}
-		return val.Value, nil
-	} else if parser.lookahead.Type == JSONScanner.Literal {
-		val, err := parser.match(parser.lookahead.Type)
-		if err != nil {
-			return nil, err
+		if m.keys == nil {
+			m.keys = make(map[string]*JSONScanner.Token)
+		}
+		m.keys[k] = key
+	case DuplicateKeysCollect:
+		if exists && m.collected[k] {
+			val = append(existing.([]interface{}), val)
+		} else if exists {
+			val = []interface{}{existing, val}
+			if m.collected == nil {
+				m.collected = make(map[string]bool)
+			}
+			m.collected[k] = true
 		}
-		return val.Value, nil
-	} else {
-		return nil, fmt.Errorf("invalid token \"%v\" looking for beginning of Value", parser.lookahead.Value)
 	}
+
+	m.set(k, val)
+	return nil
 }
 
-func (parser *JSONParser) parseObject() (interface{}, error) {
-	_, err := parser.match(JSONScanner.LeftBracket)
-	if err != nil {
-		return nil, fmt.Errorf("%s\nlooking for beginning of object key string\n", err.Error())
+// container is an object or an array whose elements parseValue is parsing
+type container struct {
+	// object is nil for an array
+	object *members
+	array  []interface{}
+	// key is the key of the member whose value is being parsed
+	key *JSONScanner.Token
+	// count is the number of members or elements parsed so far
+	count int
+}
+
+func (c *container) value() interface{} {
+	if c.object != nil {
+		return c.object.obj
 	}
-	obj := make(map[string]interface{})
+	return c.array
+}
 
-	if parser.lookahead.Type == JSONScanner.String {
-		key, err := parser.match(JSONScanner.String)
+// parseValue keeps the objects and arrays being parsed on an explicit stack instead of recursing,
+// so the nesting is bounded by Options.MaxDepth and not by the size of the goroutine stack.
+// The token following the value is not read, lookahead is nil once it returns.
+func (parser *JSONParser) parseValue() (interface{}, error) {
+	var stack []*container
+	for {
+		value, open, err := parser.beginValue(len(stack))
 		if err != nil {
 			return nil, err
 		}
-		_, err = parser.match(JSONScanner.Colon)
-		if err != nil {
-			return nil, fmt.Errorf("invalid token \"%v\" looking for Colon=\":\"", parser.lookahead.Value)
-		}
-		val, err := parser.parseValue()
-		if err != nil {
-			return nil, err
+		if open != nil {
+			stack = append(stack, open)
+			continue
 		}
-		obj[key.Value.(string)] = val
 
-		for parser.lookahead.Type == JSONScanner.Comma {
-			_, err := parser.match(JSONScanner.Comma)
-			if err != nil {
-				return nil, fmt.Errorf("%s\nlooking for beginning of object key string\n", err.Error())
+		// the value is complete, store it and close the containers ending with it
+		for {
+			if len(stack) == 0 {
+				return value, nil
 			}
-			key, err := parser.match(JSONScanner.String)
+			top := stack[len(stack)-1]
+			more, err := parser.endElement(top, value, len(stack) == 1)
 			if err != nil {
-				return nil, fmt.Errorf("invalid token \"%v\" looking for beginning of object key string", parser.lookahead.Value)
+				return nil, err
 			}
-
-			_, err = parser.match(JSONScanner.Colon)
-			if err != nil {
-				return nil, fmt.Errorf("invalid token \"%v\" looking for Colon=\":\"", parser.lookahead.Value)
+			if more {
+				break
 			}
+			stack = stack[:len(stack)-1]
+			value = top.value()
+		}
+	}
+}
 
-			val, err = parser.parseValue()
-			if err != nil {
-				return nil, err
-			}
-			obj[key.Value.(string)] = val
+// beginValue parses a scalar or an empty object or array and returns it,
+// for an object or an array with elements it returns the open container instead
+func (parser *JSONParser) beginValue(depth int) (interface{}, *container, error) {
+	switch parser.lookahead.Type {
+	case JSONScanner.LeftBracket:
+		return parser.beginObject(depth + 1)
+	case JSONScanner.LeftSquareBracket:
+		return parser.beginArray(depth + 1)
+	case JSONScanner.String, JSONScanner.Number, JSONScanner.Literal:
+		val, err := parser.matchEnd(parser.lookahead.Type, "looking for beginning of Value", depth == 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		return val.Value, nil, nil
+	case JSONScanner.Identifier:
+		value, ok := json5Literals[parser.lookahead.Value.(string)]
+		if !parser.options.JSON5 || !ok {
+			return nil, nil, parser.unexpected("looking for beginning of Value", valueTypes...)
 		}
-		if parser.lookahead.Type != JSONScanner.RightBracket {
-			return nil, fmt.Errorf("invalid token \"%v\" looking for a comma or an object closing }", parser.lookahead.Value)
+		if _, err := parser.matchEnd(JSONScanner.Identifier, "looking for beginning of Value", depth == 0); err != nil {
+			return nil, nil, err
 		}
+		return value, nil, nil
+	default:
+		return nil, nil, parser.unexpected("looking for beginning of Value", valueTypes...)
+	}
+}
+
+// checkDepth fails when an object or array would be nested deeper than Options.MaxDepth
+func (parser *JSONParser) checkDepth(depth int) error {
+	maxDepth := parser.options.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = DefaultMaxDepth
+	}
+	if maxDepth > 0 && depth > maxDepth {
+		return parser.limitExceeded("MaxDepth", maxDepth)
+	}
+	return nil
+}
+
+// checkCount fails when the element starting at the lookahead goes over Limits.MaxMembers or Limits.MaxElements
+func (parser *JSONParser) checkCount(c *container) error {
+	limit, maxCount := "MaxElements", parser.options.Limits.MaxElements
+	if c.object != nil {
+		limit, maxCount = "MaxMembers", parser.options.Limits.MaxMembers
+	}
+	if maxCount > 0 && c.count > maxCount {
+		return parser.limitExceeded(limit, maxCount)
+	}
+	return nil
+}
+
+func (parser *JSONParser) beginObject(depth int) (interface{}, *container, error) {
+	if err := parser.checkDepth(depth); err != nil {
+		return nil, nil, err
+	}
+	_, err := parser.match(JSONScanner.LeftBracket, "looking for beginning of object")
+	if err != nil {
+		return nil, nil, err
+	}
+	obj := &members{}
+	if parser.options.OrderedObjects {
+		obj.obj = NewOrderedObject()
+	} else {
+		obj.obj = make(map[string]interface{})
+	}
+
+	if parser.startsKey() {
+		key, err := parser.parseKey()
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, &container{object: obj, key: key, count: 1}, nil
 	} else if parser.lookahead.Type != JSONScanner.RightBracket {
-		return nil, fmt.Errorf("invalid token \"%v\" looking object closing }", parser.lookahead.Value)
+		return nil, nil, parser.unexpected("looking for beginning of object key string or object closing }", append(parser.keyTypes(), JSONScanner.RightBracket)...)
 	}
-	_, err = parser.match(JSONScanner.RightBracket)
+	_, err = parser.matchEnd(JSONScanner.RightBracket, "looking for object closing }", depth == 1)
 	if err != nil {
-		return nil, fmt.Errorf("%s\nlooking object closing }", err.Error())
+		return nil, nil, err
 	}
-	return obj, nil
+	return obj.obj, nil, nil
 }
 
-func (parser *JSONParser) parseArray() (interface{}, error) {
+func (parser *JSONParser) beginArray(depth int) (interface{}, *container, error) {
+	if err := parser.checkDepth(depth); err != nil {
+		return nil, nil, err
+	}
 	array := make([]interface{}, 0)
-	_, err := parser.match(JSONScanner.LeftSquareBracket)
+	_, err := parser.match(JSONScanner.LeftSquareBracket, "looking for beginning of the array")
+	if err != nil {
+		return nil, nil, err
+	}
+	if parser.startsValue() {
+		return nil, &container{array: array, count: 1}, nil
+	} else if parser.lookahead.Type != JSONScanner.RightSquareBracket {
+		return nil, nil, parser.unexpected("looking for beginning of a Value or an ending of the array", append(valueTypes, JSONScanner.RightSquareBracket)...)
+	}
+	_, err = parser.matchEnd(JSONScanner.RightSquareBracket, "looking for an ending of the array", depth == 1)
 	if err != nil {
-		return nil, fmt.Errorf("%s\nlooking for a value or an ending of the array\n", err.Error())
+		return nil, nil, err
 	}
-	if parser.lookahead.Type == JSONScanner.Number ||
-		parser.lookahead.Type == JSONScanner.String ||
-		parser.lookahead.Type == JSONScanner.LeftSquareBracket ||
-		parser.lookahead.Type == JSONScanner.LeftBracket ||
-		parser.lookahead.Type == JSONScanner.Literal {
+	return array, nil, nil
+}
 
-		Value, err := parser.parseValue()
-		if err != nil {
-			return nil, err
+// parseKey parses a key string and the colon of an object member
+func (parser *JSONParser) parseKey() (*JSONScanner.Token, error) {
+	if !parser.startsKey() {
+		return nil, parser.unexpected("looking for beginning of object key string", parser.keyTypes()...)
+	}
+	key, err := parser.match(parser.lookahead.Type, "looking for beginning of object key string")
+	if err != nil {
+		return nil, err
+	}
+	_, err = parser.match(JSONScanner.Colon, "looking for Colon=\":\"")
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// endElement stores the value in the container and parses what follows it,
+// it returns true when another element follows, with its key already parsed for an object,
+// and false once the closing token of the container is consumed, the last one of the top level value when last is set
+func (parser *JSONParser) endElement(c *container, value interface{}, last bool) (bool, error) {
+	closing := JSONScanner.RightSquareBracket
+	if c.object != nil {
+		closing = JSONScanner.RightBracket
+		if err := parser.addMember(c.object, c.key, value); err != nil {
+			return false, err
 		}
-		array = append(array, Value)
-		for parser.lookahead.Type == JSONScanner.Comma {
-			_, err := parser.match(JSONScanner.Comma)
-			if err != nil {
-				return nil, err
+	} else {
+		c.array = append(c.array, value)
+	}
+
+	if parser.lookahead.Type == JSONScanner.Comma {
+		comma := parser.lookahead
+		if err := parser.next(); err != nil {
+			return false, err
+		}
+		if !parser.trailingComma(comma, closing) {
+			c.count++
+			if err := parser.checkCount(c); err != nil {
+				return false, err
 			}
-			Value, err = parser.parseValue()
-			if err != nil {
-				return nil, err
+			if c.object != nil {
+				key, err := parser.parseKey()
+				if err != nil {
+					return false, err
+				}
+				c.key = key
 			}
-			array = append(array, Value)
+			return true, nil
 		}
-		if parser.lookahead.Type != JSONScanner.RightSquareBracket {
-			return nil, fmt.Errorf("invalid token \"%v\" looking for a comma or an ending of the array", parser.lookahead.Value)
+	} else if parser.lookahead.Type != closing {
+		if c.object != nil {
+			return false, parser.unexpected("looking for a comma or an object closing }", JSONScanner.Comma, JSONScanner.RightBracket)
 		}
-	} else if parser.lookahead.Type != JSONScanner.RightSquareBracket {
-		return nil, fmt.Errorf("invalid token \"%v\" looking for beginning of a Value or an ending of the array", parser.lookahead.Value)
+		return false, parser.unexpected("looking for a comma or an ending of the array", JSONScanner.Comma, JSONScanner.RightSquareBracket)
 	}
-	_, err = parser.match(JSONScanner.RightSquareBracket)
-	if err != nil {
-		return nil, err
+
+	if c.object != nil {
+		_, err := parser.matchEnd(JSONScanner.RightBracket, "looking for object closing }", last)
+		return false, err
 	}
-	return array, nil
+	_, err := parser.matchEnd(JSONScanner.RightSquareBracket, "looking for an ending of the array", last)
+	return false, err
 }
@@ -1,11 +1,16 @@
 package JSONParser
 
 import (
+	"JSONParser/JSONScanner"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
+	"math/big"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -103,3 +108,425 @@ func TestCompareParserInvalidJson(t *testing.T) {
 	}
 
 }
+
+func TestParseReader(t *testing.T) {
+	cases := []string{"../tests/step4/valid2.json",
+		"../tests/big/posts.json",
+		"../tests/big/bitcoin.json",
+		"../tests/test/pass1.json"}
+
+	for _, filename := range cases {
+		t.Run(filename, func(t *testing.T) {
+			file, err := os.Open(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			streamed, err := ParseReader(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			input, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := Parse(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(parsed, streamed) {
+				t.Errorf("mismatch expected %v got %v", parsed, streamed)
+			}
+		})
+	}
+
+	t.Run("invalid json", func(t *testing.T) {
+		file, err := os.Open("../tests/step2/invalid.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer file.Close()
+
+		if _, err = ParseReader(file); err == nil {
+			t.Errorf("parsed invalid json")
+		}
+	})
+}
+
+var benchmarkFiles = []string{"../tests/big/posts.json",
+	"../tests/big/comments.json",
+	"../tests/big/photos.json",
+	"../tests/big/bitcoin.json"}
+
+func BenchmarkParse(b *testing.B) {
+	for _, filename := range benchmarkFiles {
+		file, err := os.ReadFile(filename)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(filename, func(b *testing.B) {
+			b.SetBytes(int64(len(file)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := Parse(file); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkNativeLibUnmarshal(b *testing.B) {
+	for _, filename := range benchmarkFiles {
+		file, err := os.ReadFile(filename)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(filename, func(b *testing.B) {
+			b.SetBytes(int64(len(file)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var goJson interface{}
+				if err := json.Unmarshal(file, &goJson); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestDuplicateKeyPolicy(t *testing.T) {
+	input := []byte(`{"a": 1, "b": {"c": 1, "c": [2]}, "a": 2, "a": 3}`)
+
+	cases := []struct {
+		policy   DuplicateKeyPolicy
+		expected interface{}
+	}{
+		{DuplicateKeysKeepLast, map[string]interface{}{
+			"a": float64(3),
+			"b": map[string]interface{}{"c": []interface{}{float64(2)}},
+		}},
+		{DuplicateKeysKeepFirst, map[string]interface{}{
+			"a": float64(1),
+			"b": map[string]interface{}{"c": float64(1)},
+		}},
+		{DuplicateKeysCollect, map[string]interface{}{
+			"a": []interface{}{float64(1), float64(2), float64(3)},
+			"b": map[string]interface{}{"c": []interface{}{float64(1), []interface{}{float64(2)}}},
+		}},
+	}
+
+	for _, c := range cases {
+		parsed, err := ParseWithOptions(input, Options{DuplicateKeys: c.policy})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(c.expected, parsed) {
+			t.Errorf("policy %d: expected %v got %v", c.policy, c.expected, parsed)
+		}
+	}
+
+	ordered, err := ParseWithOptions(input, Options{DuplicateKeys: DuplicateKeysCollect, OrderedObjects: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, _ := ordered.(*OrderedObject).Get("a"); !reflect.DeepEqual([]interface{}{float64(1), float64(2), float64(3)}, a) {
+		t.Errorf("expected the collected values got %v", a)
+	}
+
+	_, err = ParseWithOptions(input, Options{DuplicateKeys: DuplicateKeysError})
+	var duplicate *DuplicateKeyError
+	if !errors.As(err, &duplicate) {
+		t.Fatalf("expected a DuplicateKeyError got %v", err)
+	}
+	if duplicate.Key != "c" || duplicate.First.Column != 16 || duplicate.Duplicate.Column != 24 {
+		t.Errorf("unexpected duplicate %s at col %d and col %d", duplicate.Key, duplicate.First.Column, duplicate.Duplicate.Column)
+	}
+	var parseError *ParseError
+	if !errors.As(err, &parseError) || parseError.Offset != 23 {
+		t.Errorf("expected a ParseError at the duplicate key got %v", err)
+	}
+}
+
+func TestNumbersAsLiteral(t *testing.T) {
+	input := []byte(`{"id":12345678901234567890,"price":0.10,"e":1E+2,"neg":-0,"list":[9007199254740993]}`)
+
+	parsed, err := ParseWithOptions(input, Options{Numbers: JSONScanner.NumbersAsLiteral, OrderedObjects: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	object := parsed.(*OrderedObject)
+	expected := map[string]interface{}{
+		"id":    json.Number("12345678901234567890"),
+		"price": json.Number("0.10"),
+		"e":     json.Number("1E+2"),
+		"neg":   json.Number("-0"),
+		"list":  []interface{}{json.Number("9007199254740993")},
+	}
+	if !reflect.DeepEqual(expected, object.Map()) {
+		t.Errorf("expected %v got %v", expected, object.Map())
+	}
+
+	output, err := Stringify(parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(output) != string(input) {
+		t.Errorf("expected %s got %s", input, output)
+	}
+
+	for _, invalid := range []json.Number{"", "01", "1.", "+1", "1e", "1,2", "NaN"} {
+		if _, err := Stringify(invalid); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestNumbersAsInt64(t *testing.T) {
+	input := []byte(`[9007199254740993,-42,0,1.5,1e3,-9223372036854775808,9223372036854775808]`)
+
+	parsed, err := ParseWithOptions(input, Options{Numbers: JSONScanner.NumbersAsInt64})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	overflow, _ := new(big.Int).SetString("9223372036854775808", 10)
+	expected := []interface{}{
+		int64(9007199254740993),
+		int64(-42),
+		int64(0),
+		1.5,
+		float64(1000),
+		int64(math.MinInt64),
+		overflow,
+	}
+	if !reflect.DeepEqual(expected, parsed) {
+		t.Errorf("expected %v got %v", expected, parsed)
+	}
+
+	output, err := Stringify(parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(output) != `[9007199254740993,-42,0,1.5,1000,-9223372036854775808,9223372036854775808]` {
+		t.Errorf("unexpected output %s", output)
+	}
+}
+
+func TestParseComments(t *testing.T) {
+	input := []byte(`{
+		// editor settings
+		"editor.fontSize": 14, /* pixels */
+		"files.exclude": [/* none */]
+	}`)
+
+	if _, err := Parse(input); err == nil {
+		t.Errorf("comments accepted without AllowComments")
+	}
+
+	parsed, err := ParseWithOptions(input, Options{AllowComments: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{"editor.fontSize": float64(14), "files.exclude": []interface{}{}}
+	if !reflect.DeepEqual(expected, parsed) {
+		t.Errorf("expected %v got %v", expected, parsed)
+	}
+
+	_, err = ParseWithOptions([]byte("{\n/* a\nb */ \"a\" 1}"), Options{AllowComments: true})
+	var parseError *ParseError
+	if !errors.As(err, &parseError) || parseError.Line != 3 || parseError.Column != 10 {
+		t.Errorf("expected an error at Line 3, col 10 got %v", err)
+	}
+}
+
+func TestParseJSON5(t *testing.T) {
+	input, err := os.ReadFile("../tests/json5/valid.json5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Parse(input); err == nil {
+		t.Errorf("JSON5 accepted without the JSON5 option")
+	}
+
+	parsed, err := ParseWithOptions(input, Options{JSON5: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	object := parsed.(map[string]interface{})
+	special := object["$dollar_ünicode"].([]interface{})
+	if !math.IsInf(special[0].(float64), 1) || !math.IsInf(special[1].(float64), -1) || !math.IsNaN(special[2].(float64)) {
+		t.Errorf("unexpected special numbers %v", special)
+	}
+	delete(object, "$dollar_ünicode")
+
+	expected := map[string]interface{}{
+		"unquoted":            "and you can quote me on that",
+		"singleQuotes":        `I can use "double quotes" here`,
+		"lineBreaks":          `Look, Mom! No \n's!`,
+		"hexadecimal":         float64(0xdecaf),
+		"leadingDecimalPoint": .8675309,
+		"andTrailing":         float64(8675309),
+		"positiveSign":        float64(1),
+		"trailingComma":       "in objects",
+		"andIn":               []interface{}{"arrays"},
+		"backwardsCompatible": "with JSON",
+		"escapes":             "AB\x00\v'q",
+		"null":                "keywords are keys too",
+	}
+	if !reflect.DeepEqual(expected, object) {
+		t.Errorf("expected %v got %v", expected, object)
+	}
+
+	invalid := []string{
+		`{a: 1,,}`,
+		`[1,,]`,
+		`[,]`,
+		`{,}`,
+		`[undefined]`,
+		`{a b: 1}`,
+		`{'a': 1 'b': 2}`,
+	}
+	for _, input := range invalid {
+		if _, err := ParseWithOptions([]byte(input), Options{JSON5: true}); err == nil {
+			t.Errorf("%s: parsed invalid JSON5", input)
+		}
+	}
+}
+
+func TestTrailingCommas(t *testing.T) {
+	input := []byte("{\n  \"a\": [1, 2,],\n  \"b\": {\"c\": true,},\n}")
+
+	if _, err := Parse(input); err == nil {
+		t.Errorf("trailing commas accepted without AllowTrailingCommas")
+	}
+
+	var commas []*JSONScanner.Token
+	parsed, err := ParseWithOptions(input, Options{
+		AllowTrailingCommas: true,
+		OnTrailingComma: func(comma *JSONScanner.Token) {
+			commas = append(commas, comma)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"a": []interface{}{float64(1), float64(2)},
+		"b": map[string]interface{}{"c": true},
+	}
+	if !reflect.DeepEqual(expected, parsed) {
+		t.Errorf("expected %v got %v", expected, parsed)
+	}
+
+	positions := make([][2]int, len(commas))
+	for i, comma := range commas {
+		positions[i] = [2]int{comma.Line, comma.Column}
+	}
+	if !reflect.DeepEqual([][2]int{{2, 13}, {3, 18}, {3, 20}}, positions) {
+		t.Errorf("unexpected trailing comma positions %v", positions)
+	}
+
+	// a formatter strips them using the offsets
+	stripped := append([]byte{}, input...)
+	for i := len(commas) - 1; i >= 0; i-- {
+		stripped = append(stripped[:commas[i].Offset], stripped[commas[i].Offset+1:]...)
+	}
+	reparsed, err := Parse(stripped)
+	if err != nil {
+		t.Fatalf("%s\n%s", err, stripped)
+	}
+	if !reflect.DeepEqual(expected, reparsed) {
+		t.Errorf("expected %v got %v", expected, reparsed)
+	}
+
+	for _, invalid := range []string{`[1,,]`, `[,]`, `{,}`, `{"a":1,,}`, `[1],`} {
+		if _, err := ParseWithOptions([]byte(invalid), Options{AllowTrailingCommas: true}); err == nil {
+			t.Errorf("%s: parsed invalid json", invalid)
+		}
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	input := []byte(`{"a": [1, {"b": []}], "c": {}}`)
+	if _, err := ParseWithOptions(input, Options{MaxDepth: 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := ParseWithOptions(input, Options{MaxDepth: 3})
+	var limit *LimitError
+	if !errors.As(err, &limit) || limit.Limit != "MaxDepth" || limit.Max != 3 {
+		t.Fatalf("expected a MaxDepth LimitError got %v", err)
+	}
+	var parseError *ParseError
+	if !errors.As(err, &parseError) || parseError.Offset != 16 || parseError.Column != 17 {
+		t.Errorf("expected a ParseError at the innermost [ got %v", err)
+	}
+
+	deep := []byte(strings.Repeat("[", 1000000) + strings.Repeat("]", 1000000))
+	if _, err := Parse(deep); !errors.As(err, &limit) || limit.Max != DefaultMaxDepth {
+		t.Errorf("expected the default depth limit got %v", err)
+	}
+
+	// without limit the depth is bounded by the memory, not by the goroutine stack
+	parsed, err := ParseWithOptions(deep, Options{MaxDepth: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	depth := 0
+	for array, ok := parsed.([]interface{}); ok && len(array) > 0; array, ok = array[0].([]interface{}) {
+		depth++
+	}
+	if depth != 1000000-1 {
+		t.Errorf("expected 999999 nested arrays with elements got %d", depth)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	input := []byte(`{"a": [1, 2, 3], "b": "text", "c": {"d": null}}`)
+
+	cases := []struct {
+		limits JSONScanner.Limits
+		limit  string
+		offset int
+	}{
+		{JSONScanner.Limits{MaxInputSize: len(input), MaxStringLength: 4, MaxTokens: 23, MaxMembers: 3, MaxElements: 3}, "", 0},
+		{JSONScanner.Limits{MaxMembers: 2}, "MaxMembers", 30},
+		{JSONScanner.Limits{MaxElements: 2}, "MaxElements", 13},
+		{JSONScanner.Limits{MaxStringLength: 3}, "MaxStringLength", 26},
+		{JSONScanner.Limits{MaxTokens: 22}, "MaxTokens", 46},
+		{JSONScanner.Limits{MaxInputSize: len(input) - 1}, "MaxInputSize", 0},
+	}
+
+	for _, c := range cases {
+		_, err := ParseWithOptions(input, Options{Limits: c.limits})
+		if c.limit == "" {
+			if err != nil {
+				t.Errorf("%+v: unexpected error %v", c.limits, err)
+			}
+			continue
+		}
+		var limit *LimitError
+		var parseError *ParseError
+		if !errors.As(err, &limit) || limit.Limit != c.limit || !errors.As(err, &parseError) {
+			t.Errorf("%+v: expected a ParseError wrapping a %s LimitError got %v", c.limits, c.limit, err)
+		} else if parseError.Offset != c.offset {
+			t.Errorf("%+v: expected the error at offset %d got %d", c.limits, c.offset, parseError.Offset)
+		}
+	}
+
+	_, err := ParseReaderWithOptions(strings.NewReader(`[1, 2]`+strings.Repeat(" ", 10000)), Options{Limits: JSONScanner.Limits{MaxInputSize: 100}})
+	var limit *LimitError
+	if !errors.As(err, &limit) || limit.Limit != "MaxInputSize" {
+		t.Errorf("expected the reader to stop at MaxInputSize got %v", err)
+	}
+}
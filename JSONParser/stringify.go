@@ -0,0 +1,212 @@
+package JSONParser
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+const hexDigits = "0123456789abcdef"
+
+// Stringify encodes a value returned by Parse as compact json,
+// the keys of a map are written in sorted order so the output is stable
+// while an *OrderedObject keeps its own order.
+func Stringify(value interface{}) ([]byte, error) {
+	return appendValue(nil, value)
+}
+
+func appendValue(buf []byte, value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(buf, "null"...), nil
+	case bool:
+		return strconv.AppendBool(buf, v), nil
+	case float64:
+		return appendFloat(buf, v)
+	case int64:
+		return strconv.AppendInt(buf, v, 10), nil
+	case *big.Int:
+		return v.Append(buf, 10), nil
+	case json.Number:
+		if !validNumber(string(v)) {
+			return nil, fmt.Errorf("json: invalid number literal %q", v)
+		}
+		return append(buf, v...), nil
+	case string:
+		return appendString(buf, v), nil
+	case map[string]interface{}:
+		return appendObject(buf, v)
+	case *OrderedObject:
+		return appendOrderedObject(buf, v)
+	case []interface{}:
+		return appendArray(buf, v)
+	default:
+		return nil, fmt.Errorf("json: unsupported type %T", value)
+	}
+}
+
+func appendObject(buf []byte, object map[string]interface{}) ([]byte, error) {
+	keys := make([]string, 0, len(object))
+	for k := range object {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return appendMembers(buf, keys, func(k string) interface{} {
+		return object[k]
+	})
+}
+
+func appendOrderedObject(buf []byte, object *OrderedObject) ([]byte, error) {
+	return appendMembers(buf, object.Keys(), func(k string) interface{} {
+		v, _ := object.Get(k)
+		return v
+	})
+}
+
+func appendMembers(buf []byte, keys []string, get func(string) interface{}) ([]byte, error) {
+	var err error
+	buf = append(buf, '{')
+	for i, k := range keys {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = appendString(buf, k)
+		buf = append(buf, ':')
+		buf, err = appendValue(buf, get(k))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, '}'), nil
+}
+
+func appendArray(buf []byte, array []interface{}) ([]byte, error) {
+	var err error
+	buf = append(buf, '[')
+	for i, v := range array {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf, err = appendValue(buf, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, ']'), nil
+}
+
+// appendFloat writes the shortest representation that parses back to the same float64,
+// switching to the exponent form for very small or very large values like javascript does.
+func appendFloat(buf []byte, f float64) ([]byte, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, fmt.Errorf("json: unsupported value %v", f)
+	}
+
+	abs := math.Abs(f)
+	format := byte('f')
+	if abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+
+	start := len(buf)
+	buf = strconv.AppendFloat(buf, f, format, -1, 64)
+
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(buf) - start
+		if n >= 4 && buf[len(buf)-4] == 'e' && buf[len(buf)-3] == '-' && buf[len(buf)-2] == '0' {
+			buf[len(buf)-2] = buf[len(buf)-1]
+			buf = buf[:len(buf)-1]
+		}
+	}
+	return buf, nil
+}
+
+// validNumber checks the json number grammar so a json.Number can't inject arbitrary text in the output
+func validNumber(s string) bool {
+	i := 0
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+	digits := func() int {
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		return i - start
+	}
+
+	if i < len(s) && s[i] == '0' {
+		i++
+	} else if digits() == 0 {
+		return false
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		if digits() == 0 {
+			return false
+		}
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		if digits() == 0 {
+			return false
+		}
+	}
+	return i == len(s)
+}
+
+// appendString writes s as a json string, invalid utf-8 is replaced with U+FFFD
+func appendString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		b := s[i]
+		if b < utf8.RuneSelf {
+			if b >= ' ' && b != '"' && b != '\\' {
+				i++
+				continue
+			}
+			buf = append(buf, s[start:i]...)
+			switch b {
+			case '"', '\\':
+				buf = append(buf, '\\', b)
+			case '\b':
+				buf = append(buf, '\\', 'b')
+			case '\f':
+				buf = append(buf, '\\', 'f')
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', hexDigits[b>>4], hexDigits[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, `�`...)
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf = append(buf, s[start:]...)
+	return append(buf, '"')
+}
@@ -0,0 +1,95 @@
+package JSONParser
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestStringifyRoundTrip(t *testing.T) {
+	cases := []string{"../tests/step2/valid2.json",
+		"../tests/step4/valid2.json",
+		"../tests/big/posts.json",
+		"../tests/big/bitcoin.json",
+		"../tests/test/pass1.json",
+		"../tests/test/pass2.json",
+		"../tests/test/pass3.json"}
+
+	for _, filename := range cases {
+		t.Run(filename, func(t *testing.T) {
+			input, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := Parse(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			output, err := Stringify(parsed)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reparsed, err := Parse(output)
+			if err != nil {
+				t.Fatalf("%s\n%s", err, output)
+			}
+			if !reflect.DeepEqual(parsed, reparsed) {
+				t.Errorf("mismatch expected %v got %v", parsed, reparsed)
+			}
+
+			// the native encoder also sorts the keys
+			var expected bytes.Buffer
+			encoder := json.NewEncoder(&expected)
+			encoder.SetEscapeHTML(false)
+			if err := encoder.Encode(parsed); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(bytes.TrimSuffix(expected.Bytes(), []byte("\n")), output) {
+				t.Errorf("expected %s got %s", expected.Bytes(), output)
+			}
+		})
+	}
+}
+
+func TestStringifyValues(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		expected string
+	}{
+		{nil, `null`},
+		{true, `true`},
+		{float64(0), `0`},
+		{-12.5, `-12.5`},
+		{1e21, `1e+21`},
+		{1e20, `100000000000000000000`},
+		{0.000001, `0.000001`},
+		{1.5e-7, `1.5e-7`},
+		{"tab\tquote\" \u0001 ελληνικά", `"tab\tquote\" \u0001 ελληνικά"`},
+		{"invalid \xff", `"invalid �"`},
+		{[]interface{}{}, `[]`},
+		{map[string]interface{}{}, `{}`},
+		{map[string]interface{}{"b": []interface{}{1.0, "x"}, "a": nil}, `{"a":null,"b":[1,"x"]}`},
+	}
+
+	for _, c := range cases {
+		output, err := Stringify(c.value)
+		if err != nil {
+			t.Errorf("%v: %s", c.value, err)
+			continue
+		}
+		if string(output) != c.expected {
+			t.Errorf("expected %s got %s", c.expected, output)
+		}
+	}
+
+	for _, unsupported := range []interface{}{math.NaN(), math.Inf(1), 12, struct{}{}} {
+		if _, err := Stringify([]interface{}{unsupported}); err == nil {
+			t.Errorf("expected an error for %v", unsupported)
+		}
+	}
+}
@@ -0,0 +1,443 @@
+package JSONParser
+
+import (
+	"JSONParser/JSONScanner"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// UnmarshalTypeError describes a json value that can't be stored in the Go type at Path
+type UnmarshalTypeError struct {
+	Value string
+	Type  reflect.Type
+	Path  string
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("json: cannot unmarshal %s into Go value of type %s", e.Value, e.Type)
+	}
+	return fmt.Sprintf("json: cannot unmarshal %s into Go value %s of type %s", e.Value, e.Path, e.Type)
+}
+
+// Unmarshal parses the json and stores the result in the value pointed by v.
+// Structs are filled using the field names or the name in their json tag,
+// keys that don't match any field are ignored and null leaves non pointer values untouched.
+// Integers are decoded from the exact number text so they don't lose precision,
+// values stored in an interface{} get float64 numbers like Parse returns.
+func Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("json: Unmarshal expects a non nil pointer, got %T", v)
+	}
+
+	parsed, err := ParseWithOptions(data, Options{Numbers: JSONScanner.NumbersAsLiteral})
+	if err != nil {
+		return err
+	}
+
+	return assign(rv.Elem(), parsed, "")
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+var numberType = reflect.TypeOf(json.Number(""))
+
+func assign(dst reflect.Value, src interface{}, path string) error {
+	if src == nil {
+		switch dst.Kind() {
+		case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+			dst.Set(reflect.Zero(dst.Type()))
+		}
+		return nil
+	}
+
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return assign(dst.Elem(), src, path)
+	}
+
+	number, isNumber := src.(json.Number)
+
+	// numbers are passed as text too so big.Int and big.Float keep their precision
+	if text, ok := src.(string); (ok || isNumber) && dst.CanAddr() && dst.Addr().Type().Implements(textUnmarshalerType) {
+		if isNumber {
+			text = string(number)
+		}
+		err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+		if err != nil {
+			return fmt.Errorf("json: %s: %w", path, err)
+		}
+		return nil
+	}
+
+	typeError := &UnmarshalTypeError{Value: describe(src), Type: dst.Type(), Path: path}
+
+	if dst.Type() == numberType {
+		if !isNumber {
+			return typeError
+		}
+		dst.Set(reflect.ValueOf(number))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Interface:
+		if dst.NumMethod() != 0 {
+			return typeError
+		}
+		generic, err := numbersToFloat(src)
+		if err != nil {
+			return fmt.Errorf("json: %s: %w", path, err)
+		}
+		dst.Set(reflect.ValueOf(generic))
+	case reflect.Struct:
+		object, ok := src.(map[string]interface{})
+		if !ok {
+			return typeError
+		}
+		return assignStruct(dst, object, path)
+	case reflect.Map:
+		object, ok := src.(map[string]interface{})
+		if !ok {
+			return typeError
+		}
+		return assignMap(dst, object, path)
+	case reflect.Slice:
+		array, ok := src.([]interface{})
+		if !ok {
+			return typeError
+		}
+		slice := reflect.MakeSlice(dst.Type(), len(array), len(array))
+		for i, element := range array {
+			if err := assign(slice.Index(i), element, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+	case reflect.Array:
+		array, ok := src.([]interface{})
+		if !ok {
+			return typeError
+		}
+		for i := 0; i < dst.Len(); i++ {
+			if i >= len(array) {
+				dst.Index(i).Set(reflect.Zero(dst.Type().Elem()))
+				continue
+			}
+			if err := assign(dst.Index(i), array[i], fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.String:
+		s, ok := src.(string)
+		if !ok {
+			return typeError
+		}
+		dst.SetString(s)
+	case reflect.Bool:
+		b, ok := src.(bool)
+		if !ok {
+			return typeError
+		}
+		dst.SetBool(b)
+	case reflect.Float32, reflect.Float64:
+		if !isNumber {
+			return typeError
+		}
+		f, err := strconv.ParseFloat(string(number), dst.Type().Bits())
+		if err != nil {
+			return typeError
+		}
+		dst.SetFloat(f)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !isNumber {
+			return typeError
+		}
+		n, err := strconv.ParseInt(string(number), 10, dst.Type().Bits())
+		if err != nil {
+			return typeError
+		}
+		dst.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !isNumber {
+			return typeError
+		}
+		n, err := strconv.ParseUint(string(number), 10, dst.Type().Bits())
+		if err != nil {
+			return typeError
+		}
+		dst.SetUint(n)
+	default:
+		return typeError
+	}
+	return nil
+}
+
+func assignStruct(dst reflect.Value, object map[string]interface{}, path string) error {
+	fields := cachedFields(dst.Type())
+
+	for key, value := range object {
+		f := fields.lookup(key)
+		if f == nil {
+			continue
+		}
+
+		fieldValue, err := fieldByIndex(dst, f.index)
+		if err != nil {
+			return err
+		}
+		if err := assign(fieldValue, value, joinPath(path, f.name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func assignMap(dst reflect.Value, object map[string]interface{}, path string) error {
+	mapType := dst.Type()
+	keyType := mapType.Key()
+
+	if dst.IsNil() {
+		dst.Set(reflect.MakeMapWithSize(mapType, len(object)))
+	}
+
+	for key, value := range object {
+		mapKey := reflect.New(keyType).Elem()
+		switch keyType.Kind() {
+		case reflect.String:
+			mapKey.SetString(key)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, err := strconv.ParseInt(key, 10, 64)
+			if err != nil || mapKey.OverflowInt(n) {
+				return &UnmarshalTypeError{Value: "number " + key, Type: keyType, Path: path}
+			}
+			mapKey.SetInt(n)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			n, err := strconv.ParseUint(key, 10, 64)
+			if err != nil || mapKey.OverflowUint(n) {
+				return &UnmarshalTypeError{Value: "number " + key, Type: keyType, Path: path}
+			}
+			mapKey.SetUint(n)
+		default:
+			return &UnmarshalTypeError{Value: "object", Type: mapType, Path: path}
+		}
+
+		element := reflect.New(mapType.Elem()).Elem()
+		if err := assign(element, value, joinPath(path, key)); err != nil {
+			return err
+		}
+		dst.SetMapIndex(mapKey, element)
+	}
+	return nil
+}
+
+// fieldByIndex walks the embedded structs allocating the nil pointers on the way
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("json: cannot set embedded pointer to unexported struct %v", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+func describe(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64, json.Number:
+		return "number"
+	case bool:
+		return "bool"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// numbersToFloat replaces the json.Number values of a freshly parsed tree with float64
+func numbersToFloat(value interface{}) (interface{}, error) {
+	var err error
+	switch v := value.(type) {
+	case json.Number:
+		return strconv.ParseFloat(string(v), 64)
+	case map[string]interface{}:
+		for k, element := range v {
+			if v[k], err = numbersToFloat(element); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, element := range v {
+			if v[i], err = numbersToFloat(element); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return value, nil
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+type field struct {
+	name  string
+	index []int
+}
+
+type structFields struct {
+	byName map[string]*field
+	list   []*field
+}
+
+// lookup prefers the exact name and falls back to a case insensitive match
+func (fields *structFields) lookup(key string) *field {
+	if f, ok := fields.byName[key]; ok {
+		return f
+	}
+	for _, f := range fields.list {
+		if strings.EqualFold(f.name, key) {
+			return f
+		}
+	}
+	return nil
+}
+
+var fieldCache sync.Map
+
+func cachedFields(t reflect.Type) *structFields {
+	if fields, ok := fieldCache.Load(t); ok {
+		return fields.(*structFields)
+	}
+	fields := collectFields(t)
+	cached, _ := fieldCache.LoadOrStore(t, fields)
+	return cached.(*structFields)
+}
+
+// collectFields reads the exported fields of t and the fields promoted from its embedded structs
+// with the rules of encoding/json: the embedded structs are read breadth first, the shallowest field wins,
+// a tagged field wins over the untagged ones of the same depth and the other names found twice at the same depth
+// are dropped as ambiguous. A struct type already read at a shallower depth is not read again.
+func collectFields(t reflect.Type) *structFields {
+	type embeddedStruct struct {
+		t     reflect.Type
+		index []int
+	}
+	type candidate struct {
+		field  *field
+		tagged bool
+	}
+
+	// candidates holds the fields of each name found at the shallowest depth
+	candidates := make(map[string][]candidate)
+	visited := make(map[reflect.Type]bool)
+	current := []embeddedStruct{{t: t}}
+
+	for len(current) > 0 {
+		var next []embeddedStruct
+		var names []string
+		found := make(map[string][]candidate)
+
+		for _, s := range current {
+			if visited[s.t] {
+				continue
+			}
+			for i := 0; i < s.t.NumField(); i++ {
+				sf := s.t.Field(i)
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, _, _ := strings.Cut(tag, ",")
+				index := append(append([]int{}, s.index...), i)
+
+				fieldType := sf.Type
+				if fieldType.Kind() == reflect.Pointer {
+					fieldType = fieldType.Elem()
+				}
+				if sf.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+					next = append(next, embeddedStruct{t: fieldType, index: index})
+					continue
+				}
+				if !sf.IsExported() {
+					continue
+				}
+
+				tagged := name != ""
+				if !tagged {
+					name = sf.Name
+				}
+				if _, shallower := candidates[name]; shallower {
+					continue
+				}
+				if _, ok := found[name]; !ok {
+					names = append(names, name)
+				}
+				found[name] = append(found[name], candidate{field: &field{name: name, index: index}, tagged: tagged})
+			}
+		}
+
+		// the types of this depth are marked after reading all of them, so a struct embedded twice at the same depth makes its fields ambiguous
+		for _, s := range current {
+			visited[s.t] = true
+		}
+		for _, name := range names {
+			candidates[name] = found[name]
+		}
+		current = next
+	}
+
+	fields := &structFields{byName: make(map[string]*field)}
+	for name, found := range candidates {
+		var winner *field
+		if len(found) == 1 {
+			winner = found[0].field
+		} else {
+			for _, c := range found {
+				if c.tagged {
+					if winner != nil {
+						winner = nil
+						break
+					}
+					winner = c.field
+				}
+			}
+		}
+		if winner != nil {
+			fields.byName[name] = winner
+			fields.list = append(fields.list, winner)
+		}
+	}
+	sort.Slice(fields.list, func(i, j int) bool {
+		a, b := fields.list[i].index, fields.list[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return fields
+}
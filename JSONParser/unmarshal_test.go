@@ -0,0 +1,226 @@
+package JSONParser
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type Post struct {
+	UserId int    `json:"userId"`
+	Id     int    `json:"id"`
+	Title  string `json:"title,omitempty"`
+	Body   string `json:"body"`
+}
+
+type Base struct {
+	Name    string
+	Created time.Time `json:"created"`
+}
+
+type Config struct {
+	Base
+	Port     uint16            `json:"port"`
+	Debug    *bool             `json:"debug,omitempty"`
+	Ratio    float32           `json:"ratio"`
+	Hosts    []string          `json:"hosts"`
+	Limits   map[string]int    `json:"limits"`
+	Codes    map[int]string    `json:"codes"`
+	Pair     [2]int            `json:"pair"`
+	Extra    interface{}       `json:"extra"`
+	Nested   *Config           `json:"nested"`
+	Ignored  string            `json:"-"`
+	Labels   map[string]string `json:"labels"`
+	internal string
+}
+
+func TestUnmarshalMatchesNativeLib(t *testing.T) {
+	input, err := os.ReadFile("../tests/big/posts.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ours []Post
+	if err := Unmarshal(input, &ours); err != nil {
+		t.Fatal(err)
+	}
+
+	var expected []Post
+	if err := json.Unmarshal(input, &expected); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(expected, ours) {
+		t.Errorf("mismatch expected %v got %v", expected, ours)
+	}
+}
+
+func TestUnmarshalStruct(t *testing.T) {
+	input := []byte(`{
+		"Name": "server",
+		"created": "2023-01-10T12:00:00Z",
+		"port": 8080,
+		"debug": true,
+		"ratio": 0.5,
+		"hosts": ["a", "b"],
+		"limits": {"cpu": 2},
+		"codes": {"404": "not found"},
+		"pair": [1],
+		"extra": {"any": [1, "x"]},
+		"nested": {"PORT": 9090},
+		"Ignored": "value",
+		"labels": null,
+		"unknown": 1
+	}`)
+
+	config := Config{Labels: map[string]string{"old": "label"}, Pair: [2]int{5, 5}}
+	if err := Unmarshal(input, &config); err != nil {
+		t.Fatal(err)
+	}
+
+	debug := true
+	expected := Config{
+		Base:   Base{Name: "server", Created: time.Date(2023, 1, 10, 12, 0, 0, 0, time.UTC)},
+		Port:   8080,
+		Debug:  &debug,
+		Ratio:  0.5,
+		Hosts:  []string{"a", "b"},
+		Limits: map[string]int{"cpu": 2},
+		Codes:  map[int]string{404: "not found"},
+		Pair:   [2]int{1, 0},
+		Extra:  map[string]interface{}{"any": []interface{}{float64(1), "x"}},
+		Nested: &Config{Port: 9090},
+	}
+
+	if !reflect.DeepEqual(expected, config) {
+		t.Errorf("mismatch expected %+v got %+v", expected, config)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	var config Config
+	var typeError *UnmarshalTypeError
+
+	cases := map[string]string{
+		`{"port": "8080"}`:               "port",
+		`{"port": 70000}`:                "port",
+		`{"port": 1.5}`:                  "port",
+		`{"hosts": [1]}`:                 "hosts[0]",
+		`{"nested": {"limits": []}}`:     "nested.limits",
+		`{"codes": {"x": "not number"}}`: "codes",
+	}
+	for input, path := range cases {
+		err := Unmarshal([]byte(input), &config)
+		if !errors.As(err, &typeError) {
+			t.Errorf("%s: expected an UnmarshalTypeError got %v", input, err)
+			continue
+		}
+		if typeError.Path != path {
+			t.Errorf("%s: expected path %s got %s", input, path, typeError.Path)
+		}
+	}
+
+	if err := Unmarshal([]byte(`{}`), config); err == nil {
+		t.Errorf("expected an error for a non pointer value")
+	}
+	if err := Unmarshal([]byte(`{`), &config); err == nil {
+		t.Errorf("expected an error for invalid json")
+	}
+}
+
+func TestUnmarshalPreservesPrecision(t *testing.T) {
+	var numbers struct {
+		Unsigned uint64
+		Signed   int64
+		Big      *big.Int
+		Float    big.Float
+		Literal  json.Number
+		Generic  interface{}
+	}
+
+	input := []byte(`{
+		"unsigned": 18446744073709551615,
+		"signed": -9007199254740993,
+		"big": 123456789012345678901234567890,
+		"float": 1.0000000000000000009,
+		"literal": 1e-400,
+		"generic": {"n": [1.5]}
+	}`)
+	if err := Unmarshal(input, &numbers); err != nil {
+		t.Fatal(err)
+	}
+
+	if numbers.Unsigned != 18446744073709551615 || numbers.Signed != -9007199254740993 {
+		t.Errorf("lost precision %d %d", numbers.Unsigned, numbers.Signed)
+	}
+	if numbers.Big.String() != "123456789012345678901234567890" {
+		t.Errorf("lost precision %s", numbers.Big)
+	}
+	if numbers.Float.Cmp(big.NewFloat(1)) <= 0 {
+		t.Errorf("lost precision %s", numbers.Float.String())
+	}
+	if numbers.Literal != "1e-400" {
+		t.Errorf("expected the literal text got %s", numbers.Literal)
+	}
+	if !reflect.DeepEqual(map[string]interface{}{"n": []interface{}{1.5}}, numbers.Generic) {
+		t.Errorf("expected float64 numbers got %#v", numbers.Generic)
+	}
+}
+
+type node struct {
+	*node
+	Value int
+}
+
+type shallow struct {
+	Name string
+}
+
+type deep struct {
+	shallow
+	Name  string `json:"name"`
+	Other string
+}
+
+type twin struct {
+	Other string
+	Label string
+}
+
+type embedding struct {
+	deep
+	twin
+	*shallow
+	Label string `json:"label"`
+}
+
+func TestUnmarshalEmbeddedFields(t *testing.T) {
+	var n node
+	if err := Unmarshal([]byte(`{"Value": 1}`), &n); err != nil {
+		t.Fatal(err)
+	}
+	if n.Value != 1 || n.node != nil {
+		t.Errorf("unexpected node %+v", n)
+	}
+
+	input := []byte(`{"name": "n", "Other": "o", "label": "l"}`)
+	var ours, native embedding
+	if err := Unmarshal(input, &ours); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(input, &native); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(native, ours) {
+		t.Errorf("expected %+v got %+v", native, ours)
+	}
+	// Name of *shallow is at depth 1 like deep.Name and untagged, the tagged deep.Name wins,
+	// Other is ambiguous between deep and twin so it is dropped
+	if ours.deep.Name != "n" || ours.shallow != nil || ours.deep.Other != "" || ours.twin.Other != "" || ours.Label != "l" {
+		t.Errorf("unexpected fields %+v", ours)
+	}
+}
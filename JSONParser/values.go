@@ -0,0 +1,158 @@
+package JSONParser
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxExponent bounds the exponent of the json.Number values compared exactly,
+// a bigger one would make the exact value allocate a huge amount of memory
+const maxExponent = 10000
+
+// Equal reports if two parsed values are the same json.
+// Numbers are compared by value whatever the number mode they were parsed with,
+// NaN equals NaN and a json.Number with an exponent beyond ±10000 only equals the same text.
+// A map equals an *OrderedObject with the same members in any order.
+func Equal(a, b interface{}) bool {
+	if isNumber(a) {
+		if c, ok := CompareNumbers(a, b); ok {
+			return c == 0
+		}
+		return (isNaN(a) && isNaN(b)) || a == b
+	}
+
+	switch x := a.(type) {
+	case nil, bool, string:
+		return a == b
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !Equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}, *OrderedObject:
+		keys, ok := ObjectKeys(a)
+		otherKeys, otherOk := ObjectKeys(b)
+		if !ok || !otherOk || len(keys) != len(otherKeys) {
+			return false
+		}
+		for _, k := range keys {
+			va, _ := Member(a, k)
+			vb, ok := Member(b, k)
+			if !ok || !Equal(va, vb) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Number returns the exact value of a float64, int64, *big.Int or json.Number,
+// a float64 is read as the shortest decimal that parses back to it so 0.1 is exactly one tenth.
+// NaN, the infinities and a json.Number with an exponent beyond ±10000 have no value.
+func Number(v interface{}) (*big.Rat, bool) {
+	switch n := v.(type) {
+	case float64:
+		if math.IsInf(n, 0) || math.IsNaN(n) {
+			return nil, false
+		}
+		return new(big.Rat).SetString(strconv.FormatFloat(n, 'g', -1, 64))
+	case int64:
+		return new(big.Rat).SetInt64(n), true
+	case *big.Int:
+		return new(big.Rat).SetInt(n), true
+	case json.Number:
+		if i := strings.IndexAny(string(n), "eE"); i >= 0 {
+			exponent, err := strconv.Atoi(string(n[i+1:]))
+			if err != nil || exponent > maxExponent || exponent < -maxExponent {
+				return nil, false
+			}
+		}
+		return new(big.Rat).SetString(string(n))
+	}
+	return nil, false
+}
+
+// CompareNumbers returns -1, 0 or +1 as the number a is less than, equal to or greater than b
+// whatever their number modes, the infinities are beyond every other number.
+// It returns false when a value is not a number, is NaN or has no exact value.
+func CompareNumbers(a, b interface{}) (int, bool) {
+	x, xInf, ok := numberValue(a)
+	if !ok {
+		return 0, false
+	}
+	y, yInf, ok := numberValue(b)
+	if !ok {
+		return 0, false
+	}
+	if xInf != 0 || yInf != 0 {
+		switch {
+		case xInf < yInf:
+			return -1, true
+		case xInf > yInf:
+			return 1, true
+		}
+		return 0, true
+	}
+	return x.Cmp(y), true
+}
+
+// numberValue returns the value of a finite number or the sign of an infinity
+func numberValue(v interface{}) (*big.Rat, int, bool) {
+	if f, ok := v.(float64); ok && math.IsInf(f, 0) {
+		if f > 0 {
+			return nil, 1, true
+		}
+		return nil, -1, true
+	}
+	n, ok := Number(v)
+	return n, 0, ok
+}
+
+func isNumber(v interface{}) bool {
+	switch v.(type) {
+	case float64, int64, *big.Int, json.Number:
+		return true
+	}
+	return false
+}
+
+func isNaN(v interface{}) bool {
+	f, ok := v.(float64)
+	return ok && math.IsNaN(f)
+}
+
+// Clone returns a deep copy of a parsed value, the scalars are shared
+func Clone(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = Clone(e)
+		}
+		return m
+	case *OrderedObject:
+		object := NewOrderedObject()
+		for _, k := range v.keys {
+			object.Set(k, Clone(v.values[k]))
+		}
+		return object
+	case []interface{}:
+		array := make([]interface{}, len(v))
+		for i, e := range v {
+			array[i] = Clone(e)
+		}
+		return array
+	default:
+		return value
+	}
+}
@@ -0,0 +1,135 @@
+package JSONParser
+
+import (
+	"JSONParser/JSONScanner"
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	big1, _ := new(big.Int).SetString("12345678901234567890", 10)
+	big2, _ := new(big.Int).SetString("12345678901234567890", 10)
+
+	equal := [][2]interface{}{
+		{nil, nil},
+		{true, true},
+		{"a", "a"},
+		{float64(1), int64(1)},
+		{json.Number("1.0"), float64(1)},
+		{json.Number("1e2"), int64(100)},
+		{big1, big2},
+		{[]interface{}{float64(1), "a"}, []interface{}{int64(1), "a"}},
+		{map[string]interface{}{}, NewOrderedObject()},
+		{float64(0.1), json.Number("0.1")},
+		{float64(0.1), json.Number("1e-1")},
+		{math.NaN(), math.NaN()},
+		{math.Inf(1), math.Inf(1)},
+		{json.Number("1e20000"), json.Number("1e20000")},
+	}
+	for _, pair := range equal {
+		if !Equal(pair[0], pair[1]) || !Equal(pair[1], pair[0]) {
+			t.Errorf("expected %v to equal %v", pair[0], pair[1])
+		}
+	}
+
+	different := [][2]interface{}{
+		{nil, false},
+		{"1", float64(1)},
+		{float64(1), float64(2)},
+		{[]interface{}{}, map[string]interface{}{}},
+		{[]interface{}{float64(1)}, []interface{}{float64(1), float64(1)}},
+		{map[string]interface{}{"a": nil}, map[string]interface{}{"b": nil}},
+		{map[string]interface{}{"a": nil}, map[string]interface{}{}},
+		{json.Number("12345678901234567890123"), json.Number("12345678901234567890124")},
+		{json.Number("0.1"), json.Number("0.10000000000000000001")},
+		{math.NaN(), float64(1)},
+		{math.NaN(), json.Number("1")},
+		{math.Inf(1), math.Inf(-1)},
+		{math.Inf(1), json.Number("1e400")},
+		{json.Number("1e20000"), json.Number("10e19999")},
+	}
+	for _, pair := range different {
+		if Equal(pair[0], pair[1]) || Equal(pair[1], pair[0]) {
+			t.Errorf("expected %v to differ from %v", pair[0], pair[1])
+		}
+	}
+
+	ordered, err := ParseWithOptions([]byte(`{"b": [1, {"c": null}], "a": 2}`), Options{OrderedObjects: true, Numbers: JSONScanner.NumbersAsInt64})
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := Parse([]byte(`{"a": 2.0, "b": [1, {"c": null}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Equal(ordered, plain) {
+		t.Errorf("expected the documents to be equal")
+	}
+}
+
+func TestCompareNumbers(t *testing.T) {
+	ordered := []interface{}{
+		math.Inf(-1),
+		json.Number("-1e400"),
+		int64(-1),
+		float64(0.1),
+		json.Number("0.10000000000000000001"),
+		json.Number("12345678901234567890123"),
+		json.Number("12345678901234567890124"),
+		math.Inf(1),
+	}
+	for i := range ordered {
+		for j := range ordered {
+			c, ok := CompareNumbers(ordered[i], ordered[j])
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			if !ok || c != expected {
+				t.Errorf("%v and %v: expected %d got %d, %v", ordered[i], ordered[j], expected, c, ok)
+			}
+		}
+	}
+
+	for _, pair := range [][2]interface{}{{math.NaN(), float64(1)}, {float64(1), "1"}, {json.Number("1e20000"), float64(1)}} {
+		if _, ok := CompareNumbers(pair[0], pair[1]); ok {
+			t.Errorf("%v and %v: expected no order", pair[0], pair[1])
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	original, err := ParseWithOptions([]byte(`{"b": [1, {"c": "d"}], "a": {"e": true}}`), Options{OrderedObjects: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone := Clone(original).(*OrderedObject)
+	if !Equal(original, clone) {
+		t.Fatalf("expected the clone to equal the original")
+	}
+	if keys := clone.Keys(); keys[0] != "b" || keys[1] != "a" {
+		t.Errorf("expected the order to be kept got %v", keys)
+	}
+
+	b, _ := clone.Get("b")
+	b.([]interface{})[1].(*OrderedObject).Set("c", "changed")
+	a, _ := clone.Get("a")
+	a.(*OrderedObject).Delete("e")
+
+	expected, _ := ParseWithOptions([]byte(`{"b": [1, {"c": "d"}], "a": {"e": true}}`), Options{})
+	if !Equal(original, expected) {
+		t.Errorf("modifying the clone changed the original")
+	}
+
+	m := map[string]interface{}{"x": []interface{}{"y"}}
+	mc := Clone(m).(map[string]interface{})
+	mc["x"].([]interface{})[0] = "z"
+	if m["x"].([]interface{})[0] != "y" {
+		t.Errorf("modifying the clone changed the original map")
+	}
+}
@@ -0,0 +1,166 @@
+package JSONPatch
+
+import (
+	"JSONParser/JSONParser"
+	"JSONParser/JSONPointer"
+	"strconv"
+)
+
+// maxLCSCells bounds the table used to align two arrays,
+// larger arrays are patched element by element instead.
+const maxLCSCells = 1 << 22
+
+type edit int
+
+const (
+	keep edit = iota
+	remove
+	insert
+)
+
+// Generate returns a minimal patch that turns from into to.
+// Objects are patched member by member and arrays are aligned on their longest common subsequence
+// so an inserted or removed element gives a single operation, the values are copied.
+func Generate(from, to interface{}) Patch {
+	patch := Patch{}
+	generate(&patch, JSONPointer.Pointer{}, from, to)
+	return patch
+}
+
+func generate(patch *Patch, path JSONPointer.Pointer, from, to interface{}) {
+	if JSONParser.Equal(from, to) {
+		return
+	}
+
+	fromKeys, fromObject := JSONParser.ObjectKeys(from)
+	toKeys, toObject := JSONParser.ObjectKeys(to)
+	if fromObject && toObject {
+		generateObject(patch, path, from, to, fromKeys, toKeys)
+		return
+	}
+
+	fromArray, fromIsArray := from.([]interface{})
+	toArray, toIsArray := to.([]interface{})
+	if fromIsArray && toIsArray {
+		generateArray(patch, path, fromArray, toArray)
+		return
+	}
+
+	*patch = append(*patch, Operation{Op: "replace", Path: path.String(), Value: JSONParser.Clone(to)})
+}
+
+func generateObject(patch *Patch, path JSONPointer.Pointer, from, to interface{}, fromKeys, toKeys []string) {
+	for _, k := range fromKeys {
+		if _, ok := JSONParser.Member(to, k); !ok {
+			*patch = append(*patch, Operation{Op: "remove", Path: path.Append(k).String()})
+		}
+	}
+	for _, k := range fromKeys {
+		if toValue, ok := JSONParser.Member(to, k); ok {
+			fromValue, _ := JSONParser.Member(from, k)
+			generate(patch, path.Append(k), fromValue, toValue)
+		}
+	}
+	for _, k := range toKeys {
+		if _, ok := JSONParser.Member(from, k); !ok {
+			toValue, _ := JSONParser.Member(to, k)
+			*patch = append(*patch, Operation{Op: "add", Path: path.Append(k).String(), Value: JSONParser.Clone(toValue)})
+		}
+	}
+}
+
+func generateArray(patch *Patch, path JSONPointer.Pointer, from, to []interface{}) {
+	prefix := 0
+	for prefix < len(from) && prefix < len(to) && JSONParser.Equal(from[prefix], to[prefix]) {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(from)-prefix && suffix < len(to)-prefix &&
+		JSONParser.Equal(from[len(from)-1-suffix], to[len(to)-1-suffix]) {
+		suffix++
+	}
+	a := from[prefix : len(from)-suffix]
+	b := to[prefix : len(to)-suffix]
+
+	// the position in the array patched by the operations so far
+	position := prefix
+	script := editScript(a, b)
+	i, j := 0, 0
+	for k := 0; k < len(script); {
+		if script[k] == keep {
+			i, j, position, k = i+1, j+1, position+1, k+1
+			continue
+		}
+
+		// a run of removals and insertions, the pairs are patched in place
+		removed, inserted := 0, 0
+		for ; k < len(script) && script[k] != keep; k++ {
+			if script[k] == remove {
+				removed++
+			} else {
+				inserted++
+			}
+		}
+		replaced := min(removed, inserted)
+		for t := 0; t < replaced; t++ {
+			generate(patch, path.Append(strconv.Itoa(position)), a[i+t], b[j+t])
+			position++
+		}
+		for t := replaced; t < removed; t++ {
+			*patch = append(*patch, Operation{Op: "remove", Path: path.Append(strconv.Itoa(position)).String()})
+		}
+		for t := replaced; t < inserted; t++ {
+			*patch = append(*patch, Operation{Op: "add", Path: path.Append(strconv.Itoa(position)).String(), Value: JSONParser.Clone(b[j+t])})
+			position++
+		}
+		i += removed
+		j += inserted
+	}
+}
+
+// editScript returns the edits turning a into b keeping their longest common subsequence
+func editScript(a, b []interface{}) []edit {
+	n, m := len(a), len(b)
+	script := make([]edit, 0, n+m)
+	if n*m > maxLCSCells {
+		for range a {
+			script = append(script, remove)
+		}
+		for range b {
+			script = append(script, insert)
+		}
+		return script
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if JSONParser.Equal(a[i], b[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && JSONParser.Equal(a[i], b[j]):
+			script = append(script, keep)
+			i++
+			j++
+		case j == m || (i < n && lcs[i+1][j] >= lcs[i][j+1]):
+			script = append(script, remove)
+			i++
+		default:
+			script = append(script, insert)
+			j++
+		}
+	}
+	return script
+}
@@ -0,0 +1,83 @@
+package JSONPatch
+
+import (
+	"JSONParser/JSONParser"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		from, to, expected string
+	}{
+		{`{"a": 1}`, `{"a": 1}`, `[]`},
+		{`{"a": 1, "b": 2}`, `{"a": 1, "c": 3}`,
+			`[{"op":"remove","path":"/b"},{"op":"add","path":"/c","value":3}]`},
+		{`{"a": {"b": [1, 2]}}`, `{"a": {"b": [1, 3]}}`,
+			`[{"op":"replace","path":"/a/b/1","value":3}]`},
+		{`[1, 2, 3, 4]`, `[1, 2, 9, 3, 4]`,
+			`[{"op":"add","path":"/2","value":9}]`},
+		{`[1, 2, 3, 4]`, `[1, 3, 4]`,
+			`[{"op":"remove","path":"/1"}]`},
+		{`[1, 2, 3, 4, 5]`, `[0, 2, 4, 5, 6]`,
+			`[{"op":"replace","path":"/0","value":0},{"op":"remove","path":"/2"},{"op":"add","path":"/4","value":6}]`},
+		{`[{"id": 1, "v": "a"}, {"id": 2}]`, `[{"id": 1, "v": "b"}, {"id": 2}]`,
+			`[{"op":"replace","path":"/0/v","value":"b"}]`},
+		{`{"a/b": {"~": 1}}`, `{"a/b": {"~": 2}}`,
+			`[{"op":"replace","path":"/a~1b/~0","value":2}]`},
+		{`{"a": [1]}`, `{"a": {"0": 1}}`,
+			`[{"op":"replace","path":"/a","value":{"0":1}}]`},
+		{`1`, `"one"`,
+			`[{"op":"replace","path":"","value":"one"}]`},
+		{`[]`, `[1, 2]`,
+			`[{"op":"add","path":"/0","value":1},{"op":"add","path":"/1","value":2}]`},
+	}
+
+	for _, test := range tests {
+		from := parse(t, test.from)
+		to := parse(t, test.to)
+
+		patch := Generate(from, to)
+		output, err := patch.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(output) != test.expected {
+			t.Errorf("%s -> %s: expected %s got %s", test.from, test.to, test.expected, output)
+		}
+
+		result, err := patch.Apply(from)
+		if err != nil {
+			t.Errorf("%s -> %s: %v", test.from, test.to, err)
+			continue
+		}
+		if !JSONParser.Equal(result, to) {
+			t.Errorf("%s -> %s: the patch produced %v", test.from, test.to, result)
+		}
+	}
+}
+
+func TestGenerateOrderedObjects(t *testing.T) {
+	from, err := JSONParser.ParseWithOptions([]byte(`{"z": 1, "y": [1, 2], "x": 3}`), JSONParser.Options{OrderedObjects: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := JSONParser.ParseWithOptions([]byte(`{"y": [2], "w": 4, "z": 1}`), JSONParser.Options{OrderedObjects: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	patch := Generate(from, to)
+	output, _ := patch.MarshalJSON()
+	expected := `[{"op":"remove","path":"/x"},{"op":"remove","path":"/y/0"},{"op":"add","path":"/w","value":4}]`
+	if string(output) != expected {
+		t.Errorf("expected %s got %s", expected, output)
+	}
+
+	result, err := patch.Apply(from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !JSONParser.Equal(result, to) {
+		t.Errorf("the patch produced %v", result)
+	}
+}
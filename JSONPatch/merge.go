@@ -0,0 +1,87 @@
+package JSONPatch
+
+import "JSONParser/JSONParser"
+
+// MergePatch applies an RFC 7396 merge patch: a null member deletes, an object merges recursively
+// and any other value replaces the target. The target is left unchanged, the result is a copy.
+func MergePatch(target, patch interface{}) interface{} {
+	return mergePatch(JSONParser.Clone(target), patch)
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	keys, ok := JSONParser.ObjectKeys(patch)
+	if !ok {
+		return JSONParser.Clone(patch)
+	}
+	if _, isObject := JSONParser.ObjectKeys(target); !isObject {
+		target = newObjectLike(patch)
+	}
+
+	for _, k := range keys {
+		value, _ := JSONParser.Member(patch, k)
+		if value == nil {
+			deleteMember(target, k)
+			continue
+		}
+		current, _ := JSONParser.Member(target, k)
+		setMember(target, k, mergePatch(current, value))
+	}
+	return target
+}
+
+// CreateMergePatch returns the merge patch that turns old into new.
+// A merge patch can't set a null value, the null members that new adds inside objects are lost
+// and an array is always replaced as a whole.
+func CreateMergePatch(old, new interface{}) interface{} {
+	newKeys, newIsObject := JSONParser.ObjectKeys(new)
+	oldKeys, oldIsObject := JSONParser.ObjectKeys(old)
+	if !newIsObject || !oldIsObject {
+		return JSONParser.Clone(new)
+	}
+
+	patch := newObjectLike(new)
+	for _, k := range oldKeys {
+		if _, ok := JSONParser.Member(new, k); !ok {
+			setMember(patch, k, nil)
+		}
+	}
+	for _, k := range newKeys {
+		newValue, _ := JSONParser.Member(new, k)
+		oldValue, ok := JSONParser.Member(old, k)
+		if !ok {
+			setMember(patch, k, JSONParser.Clone(newValue))
+			continue
+		}
+		if JSONParser.Equal(oldValue, newValue) {
+			continue
+		}
+		setMember(patch, k, CreateMergePatch(oldValue, newValue))
+	}
+	return patch
+}
+
+// newObjectLike returns an empty *JSONParser.OrderedObject or map like object
+func newObjectLike(object interface{}) interface{} {
+	if _, ok := object.(*JSONParser.OrderedObject); ok {
+		return JSONParser.NewOrderedObject()
+	}
+	return map[string]interface{}{}
+}
+
+func setMember(object interface{}, key string, value interface{}) {
+	switch o := object.(type) {
+	case map[string]interface{}:
+		o[key] = value
+	case *JSONParser.OrderedObject:
+		o.Set(key, value)
+	}
+}
+
+func deleteMember(object interface{}, key string) {
+	switch o := object.(type) {
+	case map[string]interface{}:
+		delete(o, key)
+	case *JSONParser.OrderedObject:
+		o.Delete(key)
+	}
+}
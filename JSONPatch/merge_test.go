@@ -0,0 +1,79 @@
+package JSONPatch
+
+import (
+	"JSONParser/JSONParser"
+	"testing"
+)
+
+// TestMergePatch runs the examples of RFC 7396 appendix A
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		target, patch, expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, test := range tests {
+		target := parse(t, test.target)
+		result := MergePatch(target, parse(t, test.patch))
+		if expected := parse(t, test.expected); !JSONParser.Equal(expected, result) {
+			t.Errorf("%s + %s: expected %s got %v", test.target, test.patch, test.expected, result)
+		}
+		if !JSONParser.Equal(target, parse(t, test.target)) {
+			t.Errorf("%s + %s: the target was modified", test.target, test.patch)
+		}
+	}
+}
+
+func TestMergePatchOrderedObjects(t *testing.T) {
+	options := JSONParser.Options{OrderedObjects: true}
+	target, _ := JSONParser.ParseWithOptions([]byte(`{"c": 1, "b": {"x": 1}, "a": 3}`), options)
+	patch, _ := JSONParser.ParseWithOptions([]byte(`{"b": {"y": 2}, "a": null, "d": 4}`), options)
+
+	output, err := JSONParser.Stringify(MergePatch(target, patch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"c":1,"b":{"x":1,"y":2},"d":4}`; string(output) != expected {
+		t.Errorf("expected %s got %s", expected, output)
+	}
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	tests := []struct {
+		old, new, expected string
+	}{
+		{`{"a":"b"}`, `{"a":"b"}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":"c"}`, `{"a":"c","b":null}`},
+		{`{"a":{"b":"c","d":"e"}}`, `{"a":{"b":"c","d":"f","g":["h"]}}`, `{"a":{"d":"f","g":["h"]}}`},
+		{`{"a":[1,2]}`, `{"a":[1,3]}`, `{"a":[1,3]}`},
+		{`{"a":1}`, `[1]`, `[1]`},
+		{`"x"`, `{"a":1}`, `{"a":1}`},
+	}
+
+	for _, test := range tests {
+		old := parse(t, test.old)
+		new := parse(t, test.new)
+		patch := CreateMergePatch(old, new)
+		if expected := parse(t, test.expected); !JSONParser.Equal(expected, patch) {
+			t.Errorf("%s -> %s: expected %s got %v", test.old, test.new, test.expected, patch)
+		}
+		if result := MergePatch(old, patch); !JSONParser.Equal(new, result) {
+			t.Errorf("%s -> %s: the patch produced %v", test.old, test.new, result)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package JSONPatch
+
+import (
+	"JSONParser/JSONParser"
+	"JSONParser/JSONPointer"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Operation is one RFC 6902 operation, Path and From are json pointers
+type Operation struct {
+	Op    string
+	Path  string
+	From  string
+	Value interface{}
+}
+
+// Patch is a list of operations applied in order
+type Patch []Operation
+
+// ErrTestFailed is returned when the value of a test operation differs from the document
+var ErrTestFailed = errors.New("test failed")
+
+// OperationError reports the operation that failed while applying a patch
+type OperationError struct {
+	// Index is the position of the operation in the patch
+	Index int
+	Op    Operation
+	Err   error
+}
+
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("json patch operation %d (%s %s): %v", e.Index, e.Op.Op, e.Op.Path, e.Err)
+}
+
+func (e *OperationError) Unwrap() error {
+	return e.Err
+}
+
+// ParsePatch reads a patch document like [{"op": "add", "path": "/a", "value": 1}]
+func ParsePatch(data []byte) (Patch, error) {
+	parsed, err := JSONParser.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return DecodePatch(parsed)
+}
+
+// DecodePatch converts a patch document returned by JSONParser.Parse
+func DecodePatch(document interface{}) (Patch, error) {
+	operations, ok := document.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("json patch must be an array of operations")
+	}
+
+	patch := make(Patch, len(operations))
+	for i, o := range operations {
+		object, ok := o.(map[string]interface{})
+		if ordered, isOrdered := o.(*JSONParser.OrderedObject); isOrdered {
+			object, ok = ordered.Map(), true
+		}
+		if !ok {
+			return nil, fmt.Errorf("json patch operation %d must be an object", i)
+		}
+
+		var err error
+		operation := &patch[i]
+		if operation.Op, err = stringMember(object, "op", i); err != nil {
+			return nil, err
+		}
+		if operation.Path, err = stringMember(object, "path", i); err != nil {
+			return nil, err
+		}
+
+		switch operation.Op {
+		case "add", "replace", "test":
+			value, ok := object["value"]
+			if !ok {
+				return nil, fmt.Errorf("json patch operation %d: %s requires a value", i, operation.Op)
+			}
+			operation.Value = value
+		case "move", "copy":
+			if operation.From, err = stringMember(object, "from", i); err != nil {
+				return nil, err
+			}
+		case "remove":
+		default:
+			return nil, fmt.Errorf("json patch operation %d: unknown op %q", i, operation.Op)
+		}
+	}
+	return patch, nil
+}
+
+func stringMember(object map[string]interface{}, key string, index int) (string, error) {
+	s, ok := object[key].(string)
+	if !ok {
+		return "", fmt.Errorf("json patch operation %d: %q must be a string", index, key)
+	}
+	return s, nil
+}
+
+// Value returns the patch as a tree that JSONParser.Stringify can encode
+func (patch Patch) Value() []interface{} {
+	operations := make([]interface{}, len(patch))
+	for i, operation := range patch {
+		object := JSONParser.NewOrderedObject()
+		object.Set("op", operation.Op)
+		if operation.Op == "move" || operation.Op == "copy" {
+			object.Set("from", operation.From)
+		}
+		object.Set("path", operation.Path)
+		if operation.Op == "add" || operation.Op == "replace" || operation.Op == "test" {
+			object.Set("value", operation.Value)
+		}
+		operations[i] = object
+	}
+	return operations
+}
+
+// MarshalJSON encodes the patch as a json patch document
+func (patch Patch) MarshalJSON() ([]byte, error) {
+	return JSONParser.Stringify(patch.Value())
+}
+
+// Apply returns the patched document. The patch is atomic,
+// it works on a copy so document is left unchanged when an operation fails.
+func (patch Patch) Apply(document interface{}) (interface{}, error) {
+	result := JSONParser.Clone(document)
+	for i, operation := range patch {
+		var err error
+		result, err = operation.apply(result)
+		if err != nil {
+			return nil, &OperationError{Index: i, Op: operation, Err: err}
+		}
+	}
+	return result, nil
+}
+
+func (operation Operation) apply(document interface{}) (interface{}, error) {
+	path, err := JSONPointer.Parse(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add":
+		return add(document, path, JSONParser.Clone(operation.Value))
+	case "remove":
+		return path.Delete(document)
+	case "replace":
+		if _, err := path.Get(document); err != nil {
+			return nil, err
+		}
+		return path.Set(document, JSONParser.Clone(operation.Value))
+	case "test":
+		value, err := path.Get(document)
+		if err != nil {
+			return nil, err
+		}
+		if !JSONParser.Equal(value, operation.Value) {
+			return nil, ErrTestFailed
+		}
+		return document, nil
+	case "move", "copy":
+		from, err := JSONPointer.Parse(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := from.Get(document)
+		if err != nil {
+			return nil, err
+		}
+		if operation.Op == "copy" {
+			return add(document, path, JSONParser.Clone(value))
+		}
+
+		if operation.From == operation.Path {
+			return document, nil
+		}
+		if strings.HasPrefix(operation.Path, operation.From+"/") {
+			return nil, fmt.Errorf("can't move %s into one of its children", operation.From)
+		}
+		document, err = from.Delete(document)
+		if err != nil {
+			return nil, err
+		}
+		return add(document, path, value)
+	default:
+		return nil, fmt.Errorf("unknown op %q", operation.Op)
+	}
+}
+
+// add sets a member of an object or inserts an element in an array before the index, - appends
+func add(document interface{}, path JSONPointer.Pointer, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parentPath := path.Parent()
+	parent, err := parentPath.Get(document)
+	if err != nil {
+		return nil, err
+	}
+	array, ok := parent.([]interface{})
+	if !ok {
+		return path.Set(document, value)
+	}
+
+	token := path[len(path)-1]
+	if token == "-" {
+		return parentPath.Set(document, append(array, value))
+	}
+
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || strconv.Itoa(i) != token {
+		return nil, fmt.Errorf("json pointer %s: invalid array index %q", path, token)
+	}
+	// the length is a valid index for add, the element is appended
+	if i > len(array) {
+		return nil, &JSONPointer.IndexOutOfRangeError{Pointer: path, Index: i, Len: len(array)}
+	}
+
+	inserted := make([]interface{}, 0, len(array)+1)
+	inserted = append(inserted, array[:i]...)
+	inserted = append(inserted, value)
+	inserted = append(inserted, array[i:]...)
+	return parentPath.Set(document, inserted)
+}
@@ -0,0 +1,184 @@
+package JSONPatch
+
+import (
+	"JSONParser/JSONParser"
+	"JSONParser/JSONPointer"
+	"errors"
+	"testing"
+)
+
+func parse(t *testing.T, input string) interface{} {
+	t.Helper()
+	value, err := JSONParser.Parse([]byte(input))
+	if err != nil {
+		t.Fatalf("%s: %v", input, err)
+	}
+	return value
+}
+
+// TestApply runs the examples of RFC 6902 appendix A
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name, document, patch, expected string
+	}{
+		{"A.1 adding an object member", `{"foo": "bar"}`,
+			`[{"op": "add", "path": "/baz", "value": "qux"}]`,
+			`{"baz": "qux", "foo": "bar"}`},
+		{"A.2 adding an array element", `{"foo": ["bar", "baz"]}`,
+			`[{"op": "add", "path": "/foo/1", "value": "qux"}]`,
+			`{"foo": ["bar", "qux", "baz"]}`},
+		{"A.3 removing an object member", `{"baz": "qux", "foo": "bar"}`,
+			`[{"op": "remove", "path": "/baz"}]`,
+			`{"foo": "bar"}`},
+		{"A.4 removing an array element", `{"foo": ["bar", "qux", "baz"]}`,
+			`[{"op": "remove", "path": "/foo/1"}]`,
+			`{"foo": ["bar", "baz"]}`},
+		{"A.5 replacing a value", `{"baz": "qux", "foo": "bar"}`,
+			`[{"op": "replace", "path": "/baz", "value": "boo"}]`,
+			`{"baz": "boo", "foo": "bar"}`},
+		{"A.6 moving a value", `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+			`[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			`{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`},
+		{"A.7 moving an array element", `{"foo": ["all", "grass", "cows", "eat"]}`,
+			`[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`,
+			`{"foo": ["all", "cows", "eat", "grass"]}`},
+		{"A.8 testing a value", `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+			`[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2}]`,
+			`{"baz": "qux", "foo": ["a", 2, "c"]}`},
+		{"A.10 adding a nested member object", `{"foo": "bar"}`,
+			`[{"op": "add", "path": "/child", "value": {"grandchild": {}}}]`,
+			`{"foo": "bar", "child": {"grandchild": {}}}`},
+		{"A.11 ignoring unrecognized elements", `{"foo": "bar"}`,
+			`[{"op": "add", "path": "/baz", "value": "qux", "xyz": 123}]`,
+			`{"foo": "bar", "baz": "qux"}`},
+		{"A.14 ~ escape ordering", `{"/": 9, "~1": 10}`,
+			`[{"op": "test", "path": "/~01", "value": 10}]`,
+			`{"/": 9, "~1": 10}`},
+		{"A.16 adding an array value", `{"foo": ["bar"]}`,
+			`[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`,
+			`{"foo": ["bar", ["abc", "def"]]}`},
+		{"copy", `{"a": {"b": [1]}}`,
+			`[{"op": "copy", "from": "/a", "path": "/c"}, {"op": "add", "path": "/c/b/0", "value": 0}]`,
+			`{"a": {"b": [1]}, "c": {"b": [0, 1]}}`},
+		{"replace the document", `{"a": 1}`,
+			`[{"op": "replace", "path": "", "value": [1]}, {"op": "add", "path": "/1", "value": 2}]`,
+			`[1, 2]`},
+	}
+
+	for _, test := range tests {
+		patch, err := ParsePatch([]byte(test.patch))
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		result, err := patch.Apply(parse(t, test.document))
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if expected := parse(t, test.expected); !JSONParser.Equal(expected, result) {
+			t.Errorf("%s: expected %v got %v", test.name, expected, result)
+		}
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	var keyNotFound *JSONPointer.KeyNotFoundError
+	var outOfRange *JSONPointer.IndexOutOfRangeError
+
+	tests := []struct {
+		name, document, patch string
+		index                 int
+		check                 func(error) bool
+	}{
+		{"A.9 testing a value error", `{"baz": "qux"}`,
+			`[{"op": "test", "path": "/baz", "value": "bar"}]`, 0,
+			func(err error) bool { return errors.Is(err, ErrTestFailed) }},
+		{"A.12 adding to a nonexistent target", `{"foo": "bar"}`,
+			`[{"op": "add", "path": "/baz/bat", "value": "qux"}]`, 0,
+			func(err error) bool { return errors.As(err, &keyNotFound) }},
+		{"A.15 comparing strings and numbers", `{"/": 9, "~1": 10}`,
+			`[{"op": "test", "path": "/~01", "value": "10"}]`, 0,
+			func(err error) bool { return errors.Is(err, ErrTestFailed) }},
+		{"adding past the end", `[1, 2]`,
+			`[{"op": "add", "path": "/0", "value": 0}, {"op": "add", "path": "/4", "value": 3}]`, 1,
+			func(err error) bool { return errors.As(err, &outOfRange) }},
+		{"removing a missing member", `{"a": 1}`,
+			`[{"op": "remove", "path": "/b"}]`, 0,
+			func(err error) bool { return errors.As(err, &keyNotFound) }},
+		{"replacing a missing member", `{"a": 1}`,
+			`[{"op": "replace", "path": "/b", "value": 1}]`, 0,
+			func(err error) bool { return errors.As(err, &keyNotFound) }},
+		{"moving into a child", `{"a": {"b": 1}}`,
+			`[{"op": "move", "from": "/a", "path": "/a/c"}]`, 0,
+			func(err error) bool { return err != nil }},
+		{"invalid index", `[1]`,
+			`[{"op": "add", "path": "/01", "value": 1}]`, 0,
+			func(err error) bool { return err != nil && !errors.As(err, &outOfRange) }},
+	}
+
+	for _, test := range tests {
+		patch, err := ParsePatch([]byte(test.patch))
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		_, err = patch.Apply(parse(t, test.document))
+		var operationError *OperationError
+		if !errors.As(err, &operationError) || operationError.Index != test.index || !test.check(err) {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+	}
+}
+
+func TestApplyIsAtomic(t *testing.T) {
+	document := parse(t, `{"a": [1, 2], "b": {"c": true}}`)
+	patch, err := ParsePatch([]byte(`[
+		{"op": "remove", "path": "/a/0"},
+		{"op": "add", "path": "/b/d", "value": 1},
+		{"op": "replace", "path": "/b/c", "value": false},
+		{"op": "test", "path": "/a/0", "value": 1}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := patch.Apply(document); !errors.Is(err, ErrTestFailed) {
+		t.Fatalf("expected the test operation to fail got %v", err)
+	}
+	if !JSONParser.Equal(document, parse(t, `{"a": [1, 2], "b": {"c": true}}`)) {
+		t.Errorf("the document was modified by a failed patch %v", document)
+	}
+}
+
+func TestParsePatchErrors(t *testing.T) {
+	invalid := []string{
+		`{"op": "add"}`,
+		`[1]`,
+		`[{"path": "/a"}]`,
+		`[{"op": "add", "path": "/a"}]`,
+		`[{"op": "move", "path": "/a"}]`,
+		`[{"op": "jump", "path": "/a"}]`,
+		`[{"op": "remove", "path": 1}]`,
+	}
+	for _, input := range invalid {
+		if _, err := ParsePatch([]byte(input)); err == nil {
+			t.Errorf("%s: expected an error", input)
+		}
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	input := `[{"op":"add","path":"/a","value":{"b":null}},{"op":"move","from":"/a","path":"/c"},{"op":"remove","path":"/c"}]`
+	patch, err := ParsePatch([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := patch.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(output) != input {
+		t.Errorf("expected %s got %s", input, output)
+	}
+}
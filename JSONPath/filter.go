@@ -0,0 +1,340 @@
+package JSONPath
+
+import (
+	"JSONParser/JSONParser"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// exprType is the type system of RFC 9535 section 2.4.1 used to check the filter expressions
+type exprType int
+
+const (
+	valueType exprType = iota
+	logicalType
+	nodesType
+)
+
+// nothing is the ValueType result that is not a json value, like the value of an empty node list
+type nothingType struct{}
+
+var nothing = nothingType{}
+
+// logical is a filter expression producing true or false
+type logical interface {
+	test(ctx *evalContext) bool
+}
+
+// valueExpr produces a json value or nothing
+type valueExpr interface {
+	value(ctx *evalContext) interface{}
+}
+
+// nodesExpr produces a node list
+type nodesExpr interface {
+	nodes(ctx *evalContext) []*Node
+}
+
+type literal struct {
+	v interface{}
+}
+
+// filterQuery is a query inside a filter starting at the current node @ or at the root $
+type filterQuery struct {
+	relative bool
+	segments []segment
+}
+
+// singularValue is a singular query used as a value
+type singularValue struct {
+	query *filterQuery
+}
+
+type existsTest struct {
+	query nodesExpr
+}
+
+type orExpr []logical
+
+type andExpr []logical
+
+type notExpr struct {
+	expr logical
+}
+
+type comparison struct {
+	op          string
+	left, right valueExpr
+}
+
+type function struct {
+	params []exprType
+	result exprType
+	call   func(args []interface{}) interface{}
+}
+
+type functionCall struct {
+	name string
+	fn   *function
+	// args hold a valueExpr, logical or nodesExpr according to the parameter types
+	args []interface{}
+}
+
+func (l *literal) value(*evalContext) interface{} {
+	return l.v
+}
+
+func (q *filterQuery) nodes(ctx *evalContext) []*Node {
+	start := ctx.root
+	if q.relative {
+		start = ctx.current
+	}
+	return evalSegments(q.segments, start, ctx)
+}
+
+// singular reports if the query selects at most one node, only names and indexes without descendants
+func (q *filterQuery) singular() bool {
+	for _, seg := range q.segments {
+		if seg.descendant || len(seg.selectors) != 1 {
+			return false
+		}
+		switch seg.selectors[0].(type) {
+		case nameSelector, indexSelector:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (s *singularValue) value(ctx *evalContext) interface{} {
+	nodes := s.query.nodes(ctx)
+	if len(nodes) == 0 {
+		return nothing
+	}
+	return nodes[0].Value
+}
+
+func (e *existsTest) test(ctx *evalContext) bool {
+	return len(e.query.nodes(ctx)) > 0
+}
+
+func (e orExpr) test(ctx *evalContext) bool {
+	for _, operand := range e {
+		if operand.test(ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e andExpr) test(ctx *evalContext) bool {
+	for _, operand := range e {
+		if !operand.test(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *notExpr) test(ctx *evalContext) bool {
+	return !e.expr.test(ctx)
+}
+
+func (c *comparison) test(ctx *evalContext) bool {
+	left := c.left.value(ctx)
+	right := c.right.value(ctx)
+	switch c.op {
+	case "==":
+		return equal(left, right)
+	case "!=":
+		return !equal(left, right)
+	case "<":
+		return less(left, right)
+	case "<=":
+		return less(left, right) || equal(left, right)
+	case ">":
+		return less(right, left)
+	default:
+		return less(right, left) || equal(left, right)
+	}
+}
+
+func (f *functionCall) evalArgs(ctx *evalContext) []interface{} {
+	args := make([]interface{}, len(f.args))
+	for i, arg := range f.args {
+		switch f.fn.params[i] {
+		case valueType:
+			args[i] = arg.(valueExpr).value(ctx)
+		case logicalType:
+			args[i] = arg.(logical).test(ctx)
+		default:
+			args[i] = arg.(nodesExpr).nodes(ctx)
+		}
+	}
+	return args
+}
+
+func (f *functionCall) value(ctx *evalContext) interface{} {
+	return f.fn.call(f.evalArgs(ctx))
+}
+
+func (f *functionCall) test(ctx *evalContext) bool {
+	return f.fn.call(f.evalArgs(ctx)).(bool)
+}
+
+func (f *functionCall) nodes(ctx *evalContext) []*Node {
+	return f.fn.call(f.evalArgs(ctx)).([]*Node)
+}
+
+// equal is JSONParser.Equal extended to nothing which equals only itself
+func equal(a, b interface{}) bool {
+	if a == nothing || b == nothing {
+		return a == b
+	}
+	return JSONParser.Equal(a, b)
+}
+
+// less compares numbers and strings, any other pair is not ordered
+func less(a, b interface{}) bool {
+	if c, ok := JSONParser.CompareNumbers(a, b); ok {
+		return c < 0
+	}
+	x, ok := a.(string)
+	y, ok2 := b.(string)
+	return ok && ok2 && x < y
+}
+
+var functions = map[string]*function{
+	"length": {
+		params: []exprType{valueType},
+		result: valueType,
+		call: func(args []interface{}) interface{} {
+			switch v := args[0].(type) {
+			case string:
+				return float64(utf8.RuneCountInString(v))
+			case []interface{}:
+				return float64(len(v))
+			case map[string]interface{}:
+				return float64(len(v))
+			case *JSONParser.OrderedObject:
+				return float64(v.Len())
+			}
+			return nothing
+		},
+	},
+	"count": {
+		params: []exprType{nodesType},
+		result: valueType,
+		call: func(args []interface{}) interface{} {
+			return float64(len(args[0].([]*Node)))
+		},
+	},
+	"match": {
+		params: []exprType{valueType, valueType},
+		result: logicalType,
+		call: func(args []interface{}) interface{} {
+			return matchRegexp(args[0], args[1], true)
+		},
+	},
+	"search": {
+		params: []exprType{valueType, valueType},
+		result: logicalType,
+		call: func(args []interface{}) interface{} {
+			return matchRegexp(args[0], args[1], false)
+		},
+	},
+	"value": {
+		params: []exprType{nodesType},
+		result: valueType,
+		call: func(args []interface{}) interface{} {
+			nodes := args[0].([]*Node)
+			if len(nodes) != 1 {
+				return nothing
+			}
+			return nodes[0].Value
+		},
+	},
+}
+
+// maxRegexps bounds the cache of match and search, the patterns built from the document
+// could otherwise grow it without limit
+const maxRegexps = 256
+
+// regexps caches the compiled patterns of match and search, it is emptied when full
+var regexps = struct {
+	sync.Mutex
+	compiled map[string]*regexp.Regexp
+}{compiled: make(map[string]*regexp.Regexp)}
+
+func matchRegexp(value, pattern interface{}, full bool) bool {
+	s, ok := value.(string)
+	p, ok2 := pattern.(string)
+	if !ok || !ok2 {
+		return false
+	}
+
+	re := cachedRegexp(p, full)
+	return re != nil && re.MatchString(s)
+}
+
+// cachedRegexp returns the compiled pattern, nil when it is invalid
+func cachedRegexp(pattern string, full bool) *regexp.Regexp {
+	key := strconv.FormatBool(full) + pattern
+	regexps.Lock()
+	re, ok := regexps.compiled[key]
+	regexps.Unlock()
+	if ok {
+		return re
+	}
+
+	re, err := compileIRegexp(pattern, full)
+	if err != nil {
+		// an invalid pattern matches nothing
+		re = nil
+	}
+	regexps.Lock()
+	if len(regexps.compiled) >= maxRegexps {
+		regexps.compiled = make(map[string]*regexp.Regexp)
+	}
+	regexps.compiled[key] = re
+	regexps.Unlock()
+	return re
+}
+
+// compileIRegexp converts an RFC 9485 I-Regexp to the go syntax,
+// the only difference that matters is the dot which doesn't match \r in I-Regexp.
+func compileIRegexp(pattern string, full bool) (*regexp.Regexp, error) {
+	var builder strings.Builder
+	if full {
+		builder.WriteString(`\A(?:`)
+	}
+
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		b := pattern[i]
+		switch {
+		case b == '\\' && i+1 < len(pattern):
+			builder.WriteByte(b)
+			i++
+			builder.WriteByte(pattern[i])
+			continue
+		case b == '[':
+			inClass = true
+		case b == ']':
+			inClass = false
+		case b == '.' && !inClass:
+			builder.WriteString(`[^\n\r]`)
+			continue
+		}
+		builder.WriteByte(b)
+	}
+
+	if full {
+		builder.WriteString(`)\z`)
+	}
+	return regexp.Compile(builder.String())
+}
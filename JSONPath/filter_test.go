@@ -0,0 +1,98 @@
+package JSONPath
+
+import (
+	"JSONParser/JSONParser"
+	"JSONParser/JSONScanner"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestFilters(t *testing.T) {
+	document := parse(t, `[
+		{"name": "a", "n": 1, "tags": ["x", "y"], "o": {"k": 1}},
+		{"name": "bb", "n": 2.5, "tags": [], "o": {"k": 2}},
+		{"name": "ccc", "n": null, "tags": ["x"]},
+		{"name": "Dd\nd", "n": "3", "o": {"k": 1}}
+	]`, JSONParser.Options{})
+
+	tests := []struct {
+		query    string
+		expected []interface{}
+	}{
+		{"$[?@.n == 1].name", []interface{}{"a"}},
+		{"$[?@.n > 1].name", []interface{}{"bb"}},
+		{"$[?@.n >= 1 && @.n < 3].name", []interface{}{"a", "bb"}},
+		{"$[?@.n == null].name", []interface{}{"ccc"}},
+		{"$[?@.n != 1].name", []interface{}{"bb", "ccc", "Dd\nd"}},
+		{"$[?@.missing == @.other].name", []interface{}{"a", "bb", "ccc", "Dd\nd"}},
+		{"$[?@.n < '4'].name", []interface{}{"Dd\nd"}},
+		{"$[?@.o.k == $[0].o.k].name", []interface{}{"a", "Dd\nd"}},
+		{"$[?@.tags == $[0].tags].name", []interface{}{"a"}},
+		{"$[?!@.o].name", []interface{}{"ccc"}},
+		{"$[?!(@.n == 1 || @.n == 2.5)].name", []interface{}{"ccc", "Dd\nd"}},
+		{"$[?@.tags[?@ == 'x']].name", []interface{}{"a", "ccc"}},
+		{"$[?length(@.name) == 3].name", []interface{}{"ccc"}},
+		{"$[?length(@.tags) == 0].name", []interface{}{"bb"}},
+		{"$[?count(@.tags[*]) > 1].name", []interface{}{"a"}},
+		{"$[?match(@.name, 'b+')].name", []interface{}{"bb"}},
+		{"$[?match(@.name, 'D.')].name", nil},
+		{"$[?match(@.name, 'D..')].name", nil},
+		{"$[?search(@.name, '[cd]')].name", []interface{}{"ccc", "Dd\nd"}},
+		{"$[?search(@.name, 'd.d')].name", nil},
+		{"$[?value(@..k) == 2].name", []interface{}{"bb"}},
+		{"$[?match(@.name, '(')].name", nil},
+	}
+
+	for _, test := range tests {
+		values, err := Query(test.query, document)
+		if err != nil {
+			t.Errorf("%s: %v", test.query, err)
+			continue
+		}
+		if len(values) == 0 && len(test.expected) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(test.expected, values) {
+			t.Errorf("%s: expected %v got %v", test.query, test.expected, values)
+		}
+	}
+}
+
+func TestFilterNumberModes(t *testing.T) {
+	input := `[{"id": 1}, {"id": 2.0}, {"id": 12345678901234567890}]`
+
+	modes := []JSONScanner.NumberMode{JSONScanner.NumbersAsFloat64, JSONScanner.NumbersAsLiteral, JSONScanner.NumbersAsInt64}
+	for _, mode := range modes {
+		document := parse(t, input, JSONParser.Options{Numbers: mode})
+		values, err := Query("$[?@.id == 2 || @.id > 1e19].id", document)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(values) != 2 {
+			t.Errorf("mode %d: expected 2 values got %v", mode, values)
+		}
+	}
+}
+
+func TestRegexpCacheIsBounded(t *testing.T) {
+	patterns := make([]interface{}, 3*maxRegexps)
+	for i := range patterns {
+		patterns[i] = map[string]interface{}{"name": "a" + strconv.Itoa(i), "pattern": "a" + strconv.Itoa(i)}
+	}
+
+	values, err := Query("$[?match(@.name, @.pattern)].name", patterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != len(patterns) {
+		t.Errorf("expected %d values got %d", len(patterns), len(values))
+	}
+
+	regexps.Lock()
+	cached := len(regexps.compiled)
+	regexps.Unlock()
+	if cached > maxRegexps {
+		t.Errorf("expected at most %d cached patterns got %d", maxRegexps, cached)
+	}
+}
@@ -0,0 +1,293 @@
+package JSONPath
+
+import (
+	"JSONParser/JSONParser"
+	"JSONParser/JSONPointer"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Path is a compiled RFC 9535 JSONPath query like $..[?(@.albumId==2)].url.
+// It runs against the trees returned by JSONParser.Parse, the members of a map
+// are visited in sorted key order and the members of an *JSONParser.OrderedObject in their own order.
+// A Path is safe for concurrent use.
+type Path struct {
+	text     string
+	segments []segment
+}
+
+// Node is a value selected by a query together with its location in the document
+type Node struct {
+	Value  interface{}
+	parent *Node
+	name   string
+	// index is the array index of the node, -1 for an object member
+	index int
+}
+
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+type selector interface {
+	apply(node *Node, ctx *evalContext, emit func(*Node))
+}
+
+type nameSelector string
+
+type wildcardSelector struct{}
+
+type indexSelector int
+
+type sliceSelector struct {
+	start, end *int
+	step       int
+}
+
+type filterSelector struct {
+	expr logical
+}
+
+type evalContext struct {
+	root    *Node
+	current *Node
+}
+
+// Compile parses a JSONPath expression
+func Compile(expression string) (*Path, error) {
+	parser := pathParser{input: expression}
+	segments, err := parser.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	return &Path{text: expression, segments: segments}, nil
+}
+
+// Query compiles the expression and returns the values it selects from document
+func Query(expression string, document interface{}) ([]interface{}, error) {
+	path, err := Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	return path.Values(document), nil
+}
+
+func (path *Path) String() string {
+	return path.text
+}
+
+// Query returns the nodes selected from document in the order defined by the RFC
+func (path *Path) Query(document interface{}) []*Node {
+	root := &Node{Value: document, index: -1}
+	return evalSegments(path.segments, root, &evalContext{root: root})
+}
+
+// Values returns the values of the selected nodes
+func (path *Path) Values(document interface{}) []interface{} {
+	nodes := path.Query(document)
+	values := make([]interface{}, len(nodes))
+	for i, node := range nodes {
+		values[i] = node.Value
+	}
+	return values
+}
+
+// Path returns the normalized path of the node like $['store']['book'][0]
+func (node *Node) Path() string {
+	var builder strings.Builder
+	builder.WriteByte('$')
+	for _, n := range node.ancestors() {
+		if n.index >= 0 {
+			builder.WriteByte('[')
+			builder.WriteString(strconv.Itoa(n.index))
+			builder.WriteByte(']')
+			continue
+		}
+		builder.WriteString("['")
+		writeNormalizedName(&builder, n.name)
+		builder.WriteString("']")
+	}
+	return builder.String()
+}
+
+// Pointer returns the location of the node as a json pointer
+func (node *Node) Pointer() JSONPointer.Pointer {
+	ancestors := node.ancestors()
+	pointer := make(JSONPointer.Pointer, len(ancestors))
+	for i, n := range ancestors {
+		if n.index >= 0 {
+			pointer[i] = strconv.Itoa(n.index)
+		} else {
+			pointer[i] = n.name
+		}
+	}
+	return pointer
+}
+
+// ancestors returns the nodes from the child of the root down to node
+func (node *Node) ancestors() []*Node {
+	var nodes []*Node
+	for n := node; n.parent != nil; n = n.parent {
+		nodes = append(nodes, n)
+	}
+	for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+	return nodes
+}
+
+func writeNormalizedName(builder *strings.Builder, name string) {
+	for i := 0; i < len(name); i++ {
+		b := name[i]
+		switch b {
+		case '\'':
+			builder.WriteString(`\'`)
+		case '\\':
+			builder.WriteString(`\\`)
+		case '\b':
+			builder.WriteString(`\b`)
+		case '\f':
+			builder.WriteString(`\f`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\t':
+			builder.WriteString(`\t`)
+		default:
+			if b < ' ' {
+				fmt.Fprintf(builder, `\u%04x`, b)
+			} else {
+				builder.WriteByte(b)
+			}
+		}
+	}
+}
+
+func evalSegments(segments []segment, start *Node, ctx *evalContext) []*Node {
+	nodes := []*Node{start}
+	for _, seg := range segments {
+		var selected []*Node
+		emit := func(node *Node) {
+			selected = append(selected, node)
+		}
+		for _, node := range nodes {
+			if seg.descendant {
+				descendants(node, func(d *Node) {
+					for _, s := range seg.selectors {
+						s.apply(d, ctx, emit)
+					}
+				})
+				continue
+			}
+			for _, s := range seg.selectors {
+				s.apply(node, ctx, emit)
+			}
+		}
+		nodes = selected
+		if len(nodes) == 0 {
+			break
+		}
+	}
+	return nodes
+}
+
+// descendants visits node and then every node below it, parents before their children
+func descendants(node *Node, visit func(*Node)) {
+	visit(node)
+	children(node, func(child *Node) {
+		descendants(child, visit)
+	})
+}
+
+// children visits the members of an object or the elements of an array
+func children(node *Node, visit func(*Node)) {
+	switch value := node.Value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			visit(&Node{Value: value[k], parent: node, name: k, index: -1})
+		}
+	case *JSONParser.OrderedObject:
+		for _, k := range value.Keys() {
+			v, _ := value.Get(k)
+			visit(&Node{Value: v, parent: node, name: k, index: -1})
+		}
+	case []interface{}:
+		for i, v := range value {
+			visit(&Node{Value: v, parent: node, index: i})
+		}
+	}
+}
+
+func (s nameSelector) apply(node *Node, _ *evalContext, emit func(*Node)) {
+	if v, ok := JSONParser.Member(node.Value, string(s)); ok {
+		emit(&Node{Value: v, parent: node, name: string(s), index: -1})
+	}
+}
+
+func (wildcardSelector) apply(node *Node, _ *evalContext, emit func(*Node)) {
+	children(node, emit)
+}
+
+func (s indexSelector) apply(node *Node, _ *evalContext, emit func(*Node)) {
+	array, ok := node.Value.([]interface{})
+	if !ok {
+		return
+	}
+	i := int(s)
+	if i < 0 {
+		i += len(array)
+	}
+	if i >= 0 && i < len(array) {
+		emit(&Node{Value: array[i], parent: node, index: i})
+	}
+}
+
+func (s sliceSelector) apply(node *Node, _ *evalContext, emit func(*Node)) {
+	array, ok := node.Value.([]interface{})
+	if !ok || s.step == 0 {
+		return
+	}
+
+	n := len(array)
+	bound := func(i *int, def int) int {
+		if i == nil {
+			return def
+		}
+		if *i < 0 {
+			return *i + n
+		}
+		return *i
+	}
+
+	if s.step > 0 {
+		lower := min(max(bound(s.start, 0), 0), n)
+		upper := min(max(bound(s.end, n), 0), n)
+		for i := lower; i < upper; i += s.step {
+			emit(&Node{Value: array[i], parent: node, index: i})
+		}
+		return
+	}
+
+	upper := min(max(bound(s.start, n-1), -1), n-1)
+	lower := min(max(bound(s.end, -n-1), -1), n-1)
+	for i := upper; lower < i; i += s.step {
+		emit(&Node{Value: array[i], parent: node, index: i})
+	}
+}
+
+func (s filterSelector) apply(node *Node, ctx *evalContext, emit func(*Node)) {
+	children(node, func(child *Node) {
+		if s.expr.test(&evalContext{root: ctx.root, current: child}) {
+			emit(child)
+		}
+	})
+}
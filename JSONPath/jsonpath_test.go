@@ -0,0 +1,202 @@
+package JSONPath
+
+import (
+	"JSONParser/JSONParser"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// bookstore is the example document of RFC 9535 section 1.5
+const bookstore = `{ "store": {
+	"book": [
+		{ "category": "reference",
+			"author": "Nigel Rees",
+			"title": "Sayings of the Century",
+			"price": 8.95
+		},
+		{ "category": "fiction",
+			"author": "Evelyn Waugh",
+			"title": "Sword of Honour",
+			"price": 12.99
+		},
+		{ "category": "fiction",
+			"author": "Herman Melville",
+			"title": "Moby Dick",
+			"isbn": "0-553-21311-3",
+			"price": 8.99
+		},
+		{ "category": "fiction",
+			"author": "J. R. R. Tolkien",
+			"title": "The Lord of the Rings",
+			"isbn": "0-395-19395-8",
+			"price": 22.99
+		}
+	],
+	"bicycle": {
+		"color": "red",
+		"price": 399
+	}
+}}`
+
+func parse(t testing.TB, input string, options JSONParser.Options) interface{} {
+	t.Helper()
+	document, err := JSONParser.ParseWithOptions([]byte(input), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return document
+}
+
+func queryPaths(t *testing.T, expression string, document interface{}) []string {
+	t.Helper()
+	path, err := Compile(expression)
+	if err != nil {
+		t.Fatalf("%s: %v", expression, err)
+	}
+	var paths []string
+	for _, node := range path.Query(document) {
+		paths = append(paths, node.Path())
+	}
+	return paths
+}
+
+func TestBookstore(t *testing.T) {
+	document := parse(t, bookstore, JSONParser.Options{OrderedObjects: true})
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"$.store.book[*].author", []string{
+			"$['store']['book'][0]['author']", "$['store']['book'][1]['author']",
+			"$['store']['book'][2]['author']", "$['store']['book'][3]['author']",
+		}},
+		{"$..author", []string{
+			"$['store']['book'][0]['author']", "$['store']['book'][1]['author']",
+			"$['store']['book'][2]['author']", "$['store']['book'][3]['author']",
+		}},
+		{"$.store.*", []string{"$['store']['book']", "$['store']['bicycle']"}},
+		{"$.store..price", []string{
+			"$['store']['book'][0]['price']", "$['store']['book'][1]['price']",
+			"$['store']['book'][2]['price']", "$['store']['book'][3]['price']",
+			"$['store']['bicycle']['price']",
+		}},
+		{"$..book[2]", []string{"$['store']['book'][2]"}},
+		{"$..book[2].author", []string{"$['store']['book'][2]['author']"}},
+		{"$..book[2].publisher", nil},
+		{"$..book[-1]", []string{"$['store']['book'][3]"}},
+		{"$..book[0,1]", []string{"$['store']['book'][0]", "$['store']['book'][1]"}},
+		{"$..book[:2]", []string{"$['store']['book'][0]", "$['store']['book'][1]"}},
+		{"$..book[?@.isbn]", []string{"$['store']['book'][2]", "$['store']['book'][3]"}},
+		{"$..book[?@.price<10]", []string{"$['store']['book'][0]", "$['store']['book'][2]"}},
+		{`$["store"]['bicycle'] ["color"]`, []string{"$['store']['bicycle']['color']"}},
+	}
+
+	for _, test := range tests {
+		if paths := queryPaths(t, test.query, document); !reflect.DeepEqual(test.expected, paths) {
+			t.Errorf("%s: expected %v got %v", test.query, test.expected, paths)
+		}
+	}
+
+	all, err := Query("$..*", document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 27 {
+		t.Errorf("expected 27 descendants got %d", len(all))
+	}
+}
+
+func TestSlices(t *testing.T) {
+	document := parse(t, `["a", "b", "c", "d", "e", "f", "g"]`, JSONParser.Options{})
+
+	tests := []struct {
+		query    string
+		expected []interface{}
+	}{
+		{"$[1:3]", []interface{}{"b", "c"}},
+		{"$[5:]", []interface{}{"f", "g"}},
+		{"$[1:5:2]", []interface{}{"b", "d"}},
+		{"$[5:1:-2]", []interface{}{"f", "d"}},
+		{"$[::-1]", []interface{}{"g", "f", "e", "d", "c", "b", "a"}},
+		{"$[-2:]", []interface{}{"f", "g"}},
+		{"$[1:3:0]", nil},
+		{"$[10:20]", nil},
+		{"$[ 0 : 1 ]", []interface{}{"a"}},
+		{"$[-8]", nil},
+		{"$[0, 0]", []interface{}{"a", "a"}},
+	}
+
+	for _, test := range tests {
+		values, err := Query(test.query, document)
+		if err != nil {
+			t.Errorf("%s: %v", test.query, err)
+			continue
+		}
+		if len(values) == 0 && len(test.expected) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(test.expected, values) {
+			t.Errorf("%s: expected %v got %v", test.query, test.expected, values)
+		}
+	}
+}
+
+func TestNodeLocation(t *testing.T) {
+	document := parse(t, `{"a/b": {"it's": [0, {"~": true}]}}`, JSONParser.Options{})
+
+	path, err := Compile("$..[?@ == true]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes := path.Query(document)
+	if len(nodes) != 1 {
+		t.Fatalf("expected one node got %d", len(nodes))
+	}
+	if p := nodes[0].Path(); p != `$['a/b']['it\'s'][1]['~']` {
+		t.Errorf("unexpected normalized path %s", p)
+	}
+	if p := nodes[0].Pointer().String(); p != "/a~1b/it's/1/~0" {
+		t.Errorf("unexpected pointer %s", p)
+	}
+	if v, err := nodes[0].Pointer().Get(document); err != nil || v != true {
+		t.Errorf("the pointer doesn't resolve to the node %v %v", v, err)
+	}
+}
+
+func TestPhotos(t *testing.T) {
+	input, err := os.ReadFile("../tests/big/photos.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	document := parse(t, string(input), JSONParser.Options{})
+
+	urls, err := Query("$..[?(@.albumId==2)].url", document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(urls) != 50 {
+		t.Fatalf("expected the 50 photos of album 2 got %d", len(urls))
+	}
+	if urls[0] != "https://via.placeholder.com/600/8e973b" {
+		t.Errorf("unexpected first url %v", urls[0])
+	}
+}
+
+func BenchmarkQueryPhotos(b *testing.B) {
+	input, err := os.ReadFile("../tests/big/photos.json")
+	if err != nil {
+		b.Fatal(err)
+	}
+	document := parse(b, string(input), JSONParser.Options{})
+	path, err := Compile("$..[?(@.albumId==2)].url")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		path.Query(document)
+	}
+}
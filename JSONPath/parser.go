@@ -0,0 +1,675 @@
+package JSONPath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// maxInt and minInt bound the integers of a query to the exact range of I-JSON
+const maxInt = 1<<53 - 1
+const minInt = -maxInt
+
+// SyntaxError reports an invalid query and the byte offset where it was detected
+type SyntaxError struct {
+	Offset int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("jsonpath: %s at offset %d", e.Msg, e.Offset)
+}
+
+type pathParser struct {
+	input string
+	pos   int
+}
+
+func (p *pathParser) errorf(format string, args ...interface{}) *SyntaxError {
+	return &SyntaxError{Offset: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *pathParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *pathParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *pathParser) consume(s string) bool {
+	if strings.HasPrefix(p.input[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *pathParser) expect(s string) error {
+	if !p.consume(s) {
+		return p.errorf("expected %q", s)
+	}
+	return nil
+}
+
+func (p *pathParser) skipBlank() {
+	for !p.eof() {
+		switch p.input[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *pathParser) parseQuery() ([]segment, error) {
+	if err := p.expect("$"); err != nil {
+		return nil, err
+	}
+	segments, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+	if !p.eof() {
+		return nil, p.errorf("unexpected character %q", p.peek())
+	}
+	return segments, nil
+}
+
+// parseSegments reads the segments following $ or @, blanks are allowed only before a segment
+func (p *pathParser) parseSegments() ([]segment, error) {
+	var segments []segment
+	for {
+		start := p.pos
+		p.skipBlank()
+		if p.peek() != '.' && p.peek() != '[' {
+			p.pos = start
+			return segments, nil
+		}
+
+		seg, err := p.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, seg)
+	}
+}
+
+func (p *pathParser) parseSegment() (segment, error) {
+	if p.consume("..") {
+		seg := segment{descendant: true}
+		var err error
+		switch {
+		case p.peek() == '[':
+			seg.selectors, err = p.parseBracketed()
+		case p.consume("*"):
+			seg.selectors = []selector{wildcardSelector{}}
+		default:
+			var name string
+			name, err = p.parseShorthand()
+			seg.selectors = []selector{nameSelector(name)}
+		}
+		return seg, err
+	}
+
+	if p.consume(".") {
+		if p.consume("*") {
+			return segment{selectors: []selector{wildcardSelector{}}}, nil
+		}
+		name, err := p.parseShorthand()
+		return segment{selectors: []selector{nameSelector(name)}}, err
+	}
+
+	selectors, err := p.parseBracketed()
+	return segment{selectors: selectors}, err
+}
+
+func isNameFirst(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r >= 0x80
+}
+
+// parseShorthand reads the member name of .name
+func (p *pathParser) parseShorthand() (string, error) {
+	start := p.pos
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		if r == utf8.RuneError && size == 1 {
+			return "", p.errorf("invalid utf-8")
+		}
+		if !isNameFirst(r) && !(p.pos > start && r >= '0' && r <= '9') {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		return "", p.errorf("expected a member name")
+	}
+	return p.input[start:p.pos], nil
+}
+
+func (p *pathParser) parseBracketed() ([]selector, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+
+	var selectors []selector
+	for {
+		p.skipBlank()
+		s, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, s)
+
+		p.skipBlank()
+		if p.consume("]") {
+			return selectors, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *pathParser) parseSelector() (selector, error) {
+	switch b := p.peek(); {
+	case b == '\'' || b == '"':
+		name, err := p.parseString()
+		return nameSelector(name), err
+	case b == '*':
+		p.pos++
+		return wildcardSelector{}, nil
+	case b == '?':
+		p.pos++
+		p.skipBlank()
+		expr, err := p.parseLogical()
+		if err != nil {
+			return nil, err
+		}
+		return filterSelector{expr: expr}, nil
+	case b == ':' || b == '-' || isDigit(b):
+		return p.parseIndexOrSlice()
+	default:
+		return nil, p.errorf("invalid selector")
+	}
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func (p *pathParser) parseIndexOrSlice() (selector, error) {
+	var bounds [3]*int
+	colons := 0
+	for i := range bounds {
+		if i > 0 {
+			before := p.pos
+			p.skipBlank()
+			if !p.consume(":") {
+				p.pos = before
+				break
+			}
+			colons++
+			p.skipBlank()
+		}
+		if b := p.peek(); b == '-' || isDigit(b) {
+			n, err := p.parseInt()
+			if err != nil {
+				return nil, err
+			}
+			bounds[i] = &n
+		}
+	}
+
+	if colons == 0 {
+		return indexSelector(*bounds[0]), nil
+	}
+	slice := sliceSelector{start: bounds[0], end: bounds[1], step: 1}
+	if bounds[2] != nil {
+		slice.step = *bounds[2]
+	}
+	return slice, nil
+}
+
+// parseInt reads an integer without leading zeros, -0 is not allowed in selectors
+func (p *pathParser) parseInt() (int, error) {
+	start := p.pos
+	p.consume("-")
+	digits := p.pos
+	for isDigit(p.peek()) {
+		p.pos++
+	}
+
+	text := p.input[start:p.pos]
+	switch {
+	case p.pos == digits:
+		return 0, p.errorf("expected an integer")
+	case p.input[digits] == '0' && (p.pos-digits > 1 || digits > start):
+		return 0, &SyntaxError{Offset: start, Msg: fmt.Sprintf("invalid integer %s", text)}
+	}
+
+	n, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || n > maxInt || n < minInt {
+		return 0, &SyntaxError{Offset: start, Msg: fmt.Sprintf("integer %s out of range", text)}
+	}
+	return int(n), nil
+}
+
+// parseString reads a single or double quoted string literal
+func (p *pathParser) parseString() (string, error) {
+	quote := p.input[p.pos]
+	p.pos++
+
+	var builder strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated string")
+		}
+		b := p.input[p.pos]
+		switch {
+		case b == quote:
+			p.pos++
+			return builder.String(), nil
+		case b < ' ':
+			return "", p.errorf("invalid control character in string")
+		case b != '\\':
+			builder.WriteByte(b)
+			p.pos++
+			continue
+		}
+
+		p.pos++
+		escape := p.peek()
+		p.pos++
+		switch escape {
+		case quote, '\\', '/':
+			builder.WriteByte(escape)
+		case 'b':
+			builder.WriteByte('\b')
+		case 'f':
+			builder.WriteByte('\f')
+		case 'n':
+			builder.WriteByte('\n')
+		case 'r':
+			builder.WriteByte('\r')
+		case 't':
+			builder.WriteByte('\t')
+		case 'u':
+			r, err := p.parseUnicodeEscape()
+			if err != nil {
+				return "", err
+			}
+			builder.WriteRune(r)
+		default:
+			p.pos--
+			return "", p.errorf("invalid escape in string")
+		}
+	}
+}
+
+func (p *pathParser) parseHex4() (rune, error) {
+	if p.pos+4 > len(p.input) {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	n, err := strconv.ParseUint(p.input[p.pos:p.pos+4], 16, 32)
+	if err != nil {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	p.pos += 4
+	return rune(n), nil
+}
+
+// parseUnicodeEscape reads the hex digits after \u, a high surrogate must be followed by a low one
+func (p *pathParser) parseUnicodeEscape() (rune, error) {
+	r, err := p.parseHex4()
+	if err != nil {
+		return 0, err
+	}
+	if r >= 0xDC00 && r <= 0xDFFF {
+		return 0, p.errorf("unpaired surrogate in unicode escape")
+	}
+	if r < 0xD800 || r > 0xDBFF {
+		return r, nil
+	}
+
+	if !p.consume(`\u`) {
+		return 0, p.errorf("unpaired surrogate in unicode escape")
+	}
+	low, err := p.parseHex4()
+	if err != nil {
+		return 0, err
+	}
+	if low < 0xDC00 || low > 0xDFFF {
+		return 0, p.errorf("unpaired surrogate in unicode escape")
+	}
+	return utf16.DecodeRune(r, low), nil
+}
+
+// parseLogical reads a filter expression that must be true or false
+func (p *pathParser) parseLogical() (logical, error) {
+	start := p.pos
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	return p.asLogical(expr, start)
+}
+
+// parseOr returns the expression as it is when it has no operator
+// so a function argument can still be used as a value or a node list.
+func (p *pathParser) parseOr() (interface{}, error) {
+	return p.parseOperands("||", p.parseAnd, func(operands []logical) logical { return orExpr(operands) })
+}
+
+func (p *pathParser) parseAnd() (interface{}, error) {
+	return p.parseOperands("&&", p.parseBasic, func(operands []logical) logical { return andExpr(operands) })
+}
+
+func (p *pathParser) parseOperands(op string, parseOperand func() (interface{}, error), combine func([]logical) logical) (interface{}, error) {
+	start := p.pos
+	first, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	var operands []logical
+	for {
+		before := p.pos
+		p.skipBlank()
+		if !p.consume(op) {
+			p.pos = before
+			break
+		}
+		if operands == nil {
+			l, err := p.asLogical(first, start)
+			if err != nil {
+				return nil, err
+			}
+			operands = append(operands, l)
+		}
+
+		p.skipBlank()
+		operandStart := p.pos
+		operand, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		l, err := p.asLogical(operand, operandStart)
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, l)
+	}
+
+	if operands == nil {
+		return first, nil
+	}
+	return combine(operands), nil
+}
+
+var comparisonOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+func (p *pathParser) parseBasic() (interface{}, error) {
+	if p.consume("!") {
+		p.skipBlank()
+		start := p.pos
+		var operand interface{}
+		var err error
+		if p.peek() == '(' {
+			operand, err = p.parseParen()
+		} else {
+			operand, err = p.parsePrimary()
+		}
+		if err != nil {
+			return nil, err
+		}
+		l, err := p.asLogical(operand, start)
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{expr: l}, nil
+	}
+
+	if p.peek() == '(' {
+		return p.parseParen()
+	}
+
+	leftStart := p.pos
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	before := p.pos
+	p.skipBlank()
+	op := ""
+	for _, candidate := range comparisonOperators {
+		if p.consume(candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		p.pos = before
+		return left, nil
+	}
+
+	p.skipBlank()
+	rightStart := p.pos
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	leftValue, err := p.asValue(left, leftStart)
+	if err != nil {
+		return nil, err
+	}
+	rightValue, err := p.asValue(right, rightStart)
+	if err != nil {
+		return nil, err
+	}
+	return &comparison{op: op, left: leftValue, right: rightValue}, nil
+}
+
+func (p *pathParser) parseParen() (logical, error) {
+	p.pos++
+	p.skipBlank()
+	expr, err := p.parseLogical()
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+// parsePrimary reads a literal, a query or a function call
+func (p *pathParser) parsePrimary() (interface{}, error) {
+	switch b := p.peek(); {
+	case b == '@' || b == '$':
+		p.pos++
+		segments, err := p.parseSegments()
+		if err != nil {
+			return nil, err
+		}
+		return &filterQuery{relative: b == '@', segments: segments}, nil
+	case b == '\'' || b == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return &literal{v: s}, nil
+	case b == '-' || isDigit(b):
+		return p.parseNumber()
+	case b >= 'a' && b <= 'z':
+		start := p.pos
+		for b := p.peek(); (b >= 'a' && b <= 'z') || b == '_' || isDigit(b); b = p.peek() {
+			p.pos++
+		}
+		word := p.input[start:p.pos]
+		if p.peek() == '(' {
+			p.pos = start
+			return p.parseFunction(word)
+		}
+		switch word {
+		case "true":
+			return &literal{v: true}, nil
+		case "false":
+			return &literal{v: false}, nil
+		case "null":
+			return &literal{v: nil}, nil
+		}
+		p.pos = start
+		return nil, p.errorf("unexpected %q", word)
+	default:
+		return nil, p.errorf("invalid filter expression")
+	}
+}
+
+func (p *pathParser) parseNumber() (*literal, error) {
+	start := p.pos
+	p.consume("-")
+	digits := p.pos
+	for isDigit(p.peek()) {
+		p.pos++
+	}
+	if p.pos == digits || (p.input[digits] == '0' && p.pos-digits > 1) {
+		return nil, p.errorf("invalid number")
+	}
+	if p.consume(".") {
+		fraction := p.pos
+		for isDigit(p.peek()) {
+			p.pos++
+		}
+		if p.pos == fraction {
+			return nil, p.errorf("invalid number")
+		}
+	}
+	if b := p.peek(); b == 'e' || b == 'E' {
+		p.pos++
+		if b := p.peek(); b == '+' || b == '-' {
+			p.pos++
+		}
+		exponent := p.pos
+		for isDigit(p.peek()) {
+			p.pos++
+		}
+		if p.pos == exponent {
+			return nil, p.errorf("invalid number")
+		}
+	}
+
+	f, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+	if err != nil {
+		return nil, p.errorf("invalid number %s", p.input[start:p.pos])
+	}
+	return &literal{v: f}, nil
+}
+
+func (p *pathParser) parseFunction(name string) (*functionCall, error) {
+	fn, ok := functions[name]
+	if !ok {
+		return nil, p.errorf("unknown function %s", name)
+	}
+	p.pos += len(name) + 1
+
+	call := &functionCall{name: name, fn: fn}
+	p.skipBlank()
+	for !p.consume(")") {
+		if len(call.args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+			p.skipBlank()
+		}
+		if len(call.args) == len(fn.params) {
+			return nil, p.errorf("too many arguments for %s", name)
+		}
+
+		start := p.pos
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		converted, err := p.asType(arg, fn.params[len(call.args)], start)
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, converted)
+		p.skipBlank()
+	}
+
+	if len(call.args) != len(fn.params) {
+		return nil, p.errorf("%s expects %d arguments", name, len(fn.params))
+	}
+	return call, nil
+}
+
+func (p *pathParser) asType(expr interface{}, t exprType, start int) (interface{}, error) {
+	switch t {
+	case valueType:
+		return p.asValue(expr, start)
+	case logicalType:
+		return p.asLogical(expr, start)
+	default:
+		return p.asNodes(expr, start)
+	}
+}
+
+// asLogical converts a query or a function to a test, following RFC 9535 section 2.4.3
+func (p *pathParser) asLogical(expr interface{}, start int) (logical, error) {
+	switch e := expr.(type) {
+	case *filterQuery:
+		return &existsTest{query: e}, nil
+	case *functionCall:
+		switch e.fn.result {
+		case logicalType:
+			return e, nil
+		case nodesType:
+			return &existsTest{query: e}, nil
+		}
+		return nil, &SyntaxError{Offset: start, Msg: fmt.Sprintf("%s result must be compared", e.name)}
+	case *literal:
+		return nil, &SyntaxError{Offset: start, Msg: "literal must be compared"}
+	}
+	return expr.(logical), nil
+}
+
+func (p *pathParser) asValue(expr interface{}, start int) (valueExpr, error) {
+	switch e := expr.(type) {
+	case *literal:
+		return e, nil
+	case *filterQuery:
+		if !e.singular() {
+			return nil, &SyntaxError{Offset: start, Msg: "query used as a value must be singular"}
+		}
+		return &singularValue{query: e}, nil
+	case *functionCall:
+		if e.fn.result == valueType {
+			return e, nil
+		}
+		return nil, &SyntaxError{Offset: start, Msg: fmt.Sprintf("%s result is not a value", e.name)}
+	}
+	return nil, &SyntaxError{Offset: start, Msg: "expected a value"}
+}
+
+func (p *pathParser) asNodes(expr interface{}, start int) (nodesExpr, error) {
+	switch e := expr.(type) {
+	case *filterQuery:
+		return e, nil
+	case *functionCall:
+		if e.fn.result == nodesType {
+			return e, nil
+		}
+	}
+	return nil, &SyntaxError{Offset: start, Msg: "expected a query"}
+}
@@ -0,0 +1,83 @@
+package JSONPath
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		query  string
+		offset int
+	}{
+		{"", 0},
+		{"store", 0},
+		{"$.", 2},
+		{"$.1a", 2},
+		{"$[", 2},
+		{"$[0", 3},
+		{"$['a'", 5},
+		{"$['a\\x']", 5},
+		{"$[01]", 2},
+		{"$[-0]", 2},
+		{"$[9007199254740992]", 2},
+		{"$[1:2:3:4]", 7},
+		{`$[?@.o == {"k": 1}]`, 10},
+		{`$['\"']`, 4},
+		{"$ ", 1},
+		{"$[?@.a == ]", 10},
+		{"$[?@.a ==]", 9},
+		{"$[?1]", 3},
+		{"$[?@.* == 1]", 3},
+		{"$[?@..a == 1]", 3},
+		{"$[?length(@.a)]", 3},
+		{"$[?count(1) == 1]", 9},
+		{"$[?length(@.*) == 1]", 10},
+		{"$[?match(@.a) == 1]", 13},
+		{"$[?foo(@.a)]", 3},
+		{"$[?!@.a == 1]", 8},
+		{"$[?(@.a]", 7},
+		{"$[?@.a == tru]", 10},
+		{`$["\ud800"]`, 9},
+	}
+
+	for _, test := range tests {
+		_, err := Compile(test.query)
+		var syntaxError *SyntaxError
+		if !errors.As(err, &syntaxError) {
+			t.Errorf("%q: expected a SyntaxError got %v", test.query, err)
+			continue
+		}
+		if syntaxError.Offset != test.offset {
+			t.Errorf("%q: expected offset %d got %d (%v)", test.query, test.offset, syntaxError.Offset, err)
+		}
+	}
+}
+
+func TestCompileValid(t *testing.T) {
+	queries := []string{
+		"$",
+		"$.a.b_c.ü",
+		"$..*",
+		"$['a', \"b\", 0, -1, :, ::2, *]",
+		`$['é😀\'\/\b\f\n\r\t']`,
+		"$[?@.a && (@.b || !@.c)]",
+		"$[?(@.a==2)]",
+		"$[?@.a == -0.5e-3 || @.b != true || @.c <= null]",
+		"$[?$.x == @.y]",
+		"$[?count(@..a) >= 2 && value(@.b) == 'x']",
+		"$[?match(@.a, '[a-z]+') && !search(@.b, 'x')]",
+		"$ .a [0]",
+	}
+
+	for _, query := range queries {
+		path, err := Compile(query)
+		if err != nil {
+			t.Errorf("%q: %v", query, err)
+			continue
+		}
+		if path.String() != query {
+			t.Errorf("expected %q got %q", query, path.String())
+		}
+	}
+}
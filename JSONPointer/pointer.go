@@ -0,0 +1,262 @@
+package JSONPointer
+
+import (
+	"JSONParser/JSONParser"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Pointer is a parsed RFC 6901 json pointer holding the unescaped reference tokens,
+// the empty Pointer refers to the whole document.
+// It resolves against the trees returned by JSONParser.Parse:
+// map[string]interface{}, *JSONParser.OrderedObject and []interface{}.
+type Pointer []string
+
+// KeyNotFoundError is returned when an object has no member for a reference token
+type KeyNotFoundError struct {
+	// Pointer is the part of the pointer up to the missing key included
+	Pointer Pointer
+	Key     string
+}
+
+func (e *KeyNotFoundError) Error() string {
+	return fmt.Sprintf("json pointer %s: key %q not found", e.Pointer, e.Key)
+}
+
+// IndexOutOfRangeError is returned when an array index is past the end of the array
+type IndexOutOfRangeError struct {
+	// Pointer is the part of the pointer up to the index included
+	Pointer Pointer
+	Index   int
+	Len     int
+}
+
+func (e *IndexOutOfRangeError) Error() string {
+	return fmt.Sprintf("json pointer %s: index %d out of range with length %d", e.Pointer, e.Index, e.Len)
+}
+
+// Parse reads the string form of a pointer like /a/b~1c/0, ~1 stands for / and ~0 for ~
+func Parse(pointer string) (Pointer, error) {
+	if pointer == "" {
+		return Pointer{}, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("json pointer %q must start with /", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		unescaped, err := unescape(token)
+		if err != nil {
+			return nil, fmt.Errorf("json pointer %q: %w", pointer, err)
+		}
+		tokens[i] = unescaped
+	}
+	return tokens, nil
+}
+
+func unescape(token string) (string, error) {
+	if !strings.Contains(token, "~") {
+		return token, nil
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(token); i++ {
+		if token[i] != '~' {
+			builder.WriteByte(token[i])
+			continue
+		}
+		if i+1 == len(token) || (token[i+1] != '0' && token[i+1] != '1') {
+			return "", fmt.Errorf("invalid escape in %q, ~ must be followed by 0 or 1", token)
+		}
+		if token[i+1] == '0' {
+			builder.WriteByte('~')
+		} else {
+			builder.WriteByte('/')
+		}
+		i++
+	}
+	return builder.String(), nil
+}
+
+// String returns the escaped form of the pointer
+func (p Pointer) String() string {
+	var builder strings.Builder
+	for _, token := range p {
+		builder.WriteByte('/')
+		builder.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+	return builder.String()
+}
+
+// Append returns a new pointer with token added at the end
+func (p Pointer) Append(token string) Pointer {
+	appended := make(Pointer, len(p), len(p)+1)
+	copy(appended, p)
+	return append(appended, token)
+}
+
+// Parent returns the pointer without its last token, the parent of the document is the document
+func (p Pointer) Parent() Pointer {
+	if len(p) == 0 {
+		return p
+	}
+	return p[:len(p)-1]
+}
+
+// Get returns the value the pointer refers to
+func (p Pointer) Get(document interface{}) (interface{}, error) {
+	node := document
+	for i, token := range p {
+		var err error
+		node, err = child(node, token, p[:i+1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// Set replaces the value the pointer refers to or adds it when the parent object has no such key.
+// The last token - appends to an array. Objects are modified in place,
+// the returned document must be used since an array or the whole document may be replaced.
+func (p Pointer) Set(document interface{}, value interface{}) (interface{}, error) {
+	if len(p) == 0 {
+		return value, nil
+	}
+
+	return p.update(document, 0, func(parent interface{}, token string, at Pointer) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+			return node, nil
+		case *JSONParser.OrderedObject:
+			node.Set(token, value)
+			return node, nil
+		case []interface{}:
+			if token == "-" {
+				return append(node, value), nil
+			}
+			index, err := arrayIndex(node, token, at)
+			if err != nil {
+				return nil, err
+			}
+			node[index] = value
+			return node, nil
+		default:
+			return nil, notContainer(parent, at)
+		}
+	})
+}
+
+// Delete removes the value the pointer refers to from its parent, the whole document can't be deleted.
+// Like Set it returns the document to use afterwards.
+func (p Pointer) Delete(document interface{}) (interface{}, error) {
+	if len(p) == 0 {
+		return nil, fmt.Errorf("json pointer: can't delete the whole document")
+	}
+
+	return p.update(document, 0, func(parent interface{}, token string, at Pointer) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := node[token]; !ok {
+				return nil, &KeyNotFoundError{Pointer: at, Key: token}
+			}
+			delete(node, token)
+			return node, nil
+		case *JSONParser.OrderedObject:
+			if _, ok := node.Get(token); !ok {
+				return nil, &KeyNotFoundError{Pointer: at, Key: token}
+			}
+			node.Delete(token)
+			return node, nil
+		case []interface{}:
+			index, err := arrayIndex(node, token, at)
+			if err != nil {
+				return nil, err
+			}
+			// copy so the slices sharing the old backing array are left untouched
+			return append(node[:index:index], node[index+1:]...), nil
+		default:
+			return nil, notContainer(parent, at)
+		}
+	})
+}
+
+// update walks to the parent of the last token and applies change to it,
+// the containers on the way are rebuilt with the value change returns.
+func (p Pointer) update(node interface{}, depth int, change func(parent interface{}, token string, at Pointer) (interface{}, error)) (interface{}, error) {
+	if depth == len(p)-1 {
+		return change(node, p[depth], p)
+	}
+
+	at := p[:depth+1]
+	next, err := child(node, p[depth], at)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := p.update(next, depth+1, change)
+	if err != nil {
+		return nil, err
+	}
+
+	switch container := node.(type) {
+	case map[string]interface{}:
+		container[p[depth]] = updated
+	case *JSONParser.OrderedObject:
+		container.Set(p[depth], updated)
+	case []interface{}:
+		index, _ := strconv.Atoi(p[depth])
+		container[index] = updated
+	}
+	return node, nil
+}
+
+func child(node interface{}, token string, at Pointer) (interface{}, error) {
+	switch container := node.(type) {
+	case map[string]interface{}:
+		value, ok := container[token]
+		if !ok {
+			return nil, &KeyNotFoundError{Pointer: at, Key: token}
+		}
+		return value, nil
+	case *JSONParser.OrderedObject:
+		value, ok := container.Get(token)
+		if !ok {
+			return nil, &KeyNotFoundError{Pointer: at, Key: token}
+		}
+		return value, nil
+	case []interface{}:
+		index, err := arrayIndex(container, token, at)
+		if err != nil {
+			return nil, err
+		}
+		return container[index], nil
+	default:
+		return nil, notContainer(node, at)
+	}
+}
+
+// arrayIndex parses an existing index, leading zeros are not allowed and - is past the last element
+func arrayIndex(array []interface{}, token string, at Pointer) (int, error) {
+	if token == "-" {
+		return 0, &IndexOutOfRangeError{Pointer: at, Index: len(array), Len: len(array)}
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, fmt.Errorf("json pointer %s: invalid array index %q", at, token)
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("json pointer %s: invalid array index %q", at, token)
+	}
+	if index >= len(array) {
+		return 0, &IndexOutOfRangeError{Pointer: at, Index: index, Len: len(array)}
+	}
+	return index, nil
+}
+
+func notContainer(node interface{}, at Pointer) error {
+	return fmt.Errorf("json pointer %s: can't index into %T", at, node)
+}
@@ -0,0 +1,198 @@
+package JSONPointer
+
+import (
+	"JSONParser/JSONParser"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// rfcDocument is the example document of RFC 6901 section 5
+const rfcDocument = `{
+	"foo": ["bar", "baz"],
+	"": 0,
+	"a/b": 1,
+	"c%d": 2,
+	"e^f": 3,
+	"g|h": 4,
+	"i\\j": 5,
+	"k\"l": 6,
+	" ": 7,
+	"m~n": 8
+}`
+
+func parse(t *testing.T, input string, options JSONParser.Options) interface{} {
+	t.Helper()
+	document, err := JSONParser.ParseWithOptions([]byte(input), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return document
+}
+
+func TestGet(t *testing.T) {
+	for _, options := range []JSONParser.Options{{}, {OrderedObjects: true}} {
+		document := parse(t, rfcDocument, options)
+
+		tests := []struct {
+			pointer  string
+			expected interface{}
+		}{
+			{"/foo", []interface{}{"bar", "baz"}},
+			{"/foo/0", "bar"},
+			{"/", float64(0)},
+			{"/a~1b", float64(1)},
+			{"/c%d", float64(2)},
+			{"/e^f", float64(3)},
+			{"/g|h", float64(4)},
+			{"/i\\j", float64(5)},
+			{"/k\"l", float64(6)},
+			{"/ ", float64(7)},
+			{"/m~0n", float64(8)},
+		}
+
+		for _, test := range tests {
+			pointer, err := Parse(test.pointer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pointer.String() != test.pointer {
+				t.Errorf("expected %s got %s", test.pointer, pointer)
+			}
+			value, err := pointer.Get(document)
+			if err != nil {
+				t.Errorf("%s: %v", test.pointer, err)
+				continue
+			}
+			if !reflect.DeepEqual(test.expected, value) {
+				t.Errorf("%s: expected %v got %v", test.pointer, test.expected, value)
+			}
+		}
+
+		whole, err := Pointer{}.Get(document)
+		if err != nil || !reflect.DeepEqual(whole, document) {
+			t.Errorf("expected the whole document got %v %v", whole, err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, pointer := range []string{"foo", "/a~2", "/a~"} {
+		if _, err := Parse(pointer); err == nil {
+			t.Errorf("expected an error for %q", pointer)
+		}
+	}
+}
+
+func TestGetErrors(t *testing.T) {
+	document := parse(t, `{"a": {"b": [1, 2]}, "n": 1}`, JSONParser.Options{})
+
+	get := func(s string) error {
+		pointer, err := Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = pointer.Get(document)
+		return err
+	}
+
+	var keyNotFound *KeyNotFoundError
+	if err := get("/a/c"); !errors.As(err, &keyNotFound) || keyNotFound.Key != "c" || keyNotFound.Pointer.String() != "/a/c" {
+		t.Errorf("expected a KeyNotFoundError for /a/c got %v", err)
+	}
+
+	var outOfRange *IndexOutOfRangeError
+	if err := get("/a/b/2"); !errors.As(err, &outOfRange) || outOfRange.Index != 2 || outOfRange.Len != 2 {
+		t.Errorf("expected an IndexOutOfRangeError got %v", err)
+	}
+	if err := get("/a/b/-"); !errors.As(err, &outOfRange) {
+		t.Errorf("expected an IndexOutOfRangeError for - got %v", err)
+	}
+
+	for _, invalid := range []string{"/a/b/01", "/a/b/x", "/a/b/-1", "/a/b/", "/n/0"} {
+		err := get(invalid)
+		if err == nil || errors.As(err, &keyNotFound) || errors.As(err, &outOfRange) {
+			t.Errorf("%s: expected a plain error got %v", invalid, err)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	document := parse(t, `{"a": {"b": [1, 2]}}`, JSONParser.Options{})
+
+	set := func(s string, value interface{}) {
+		t.Helper()
+		pointer, err := Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		document, err = pointer.Set(document, value)
+		if err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+	}
+
+	set("/a/b/0", "one")
+	set("/a/b/-", float64(3))
+	set("/a/c", true)
+	set("/d", nil)
+
+	expected := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": []interface{}{"one", float64(2), float64(3)},
+			"c": true,
+		},
+		"d": nil,
+	}
+	if !reflect.DeepEqual(expected, document) {
+		t.Errorf("expected %v got %v", expected, document)
+	}
+
+	replaced, err := Pointer{}.Set(document, "root")
+	if err != nil || replaced != "root" {
+		t.Errorf("expected the document to be replaced got %v %v", replaced, err)
+	}
+
+	var outOfRange *IndexOutOfRangeError
+	if _, err := (Pointer{"a", "b", "5"}).Set(document, 1); !errors.As(err, &outOfRange) {
+		t.Errorf("expected an IndexOutOfRangeError got %v", err)
+	}
+	var keyNotFound *KeyNotFoundError
+	if _, err := (Pointer{"x", "y"}).Set(document, 1); !errors.As(err, &keyNotFound) {
+		t.Errorf("expected a KeyNotFoundError got %v", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	document := parse(t, `{"a": [1, 2, 3], "b": {"c": 1, "d": 2}}`, JSONParser.Options{OrderedObjects: true})
+	array, _ := (Pointer{"a"}).Get(document)
+
+	var err error
+	for _, s := range []string{"/a/1", "/b/c"} {
+		pointer, _ := Parse(s)
+		document, err = pointer.Delete(document)
+		if err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+	}
+
+	a, _ := (Pointer{"a"}).Get(document)
+	if !reflect.DeepEqual(a, []interface{}{float64(1), float64(3)}) {
+		t.Errorf("unexpected array %v", a)
+	}
+	if !reflect.DeepEqual(array, []interface{}{float64(1), float64(2), float64(3)}) {
+		t.Errorf("the previous array was modified %v", array)
+	}
+	b, _ := (Pointer{"b"}).Get(document)
+	if keys := b.(*JSONParser.OrderedObject).Keys(); !reflect.DeepEqual(keys, []string{"d"}) {
+		t.Errorf("unexpected keys %v", keys)
+	}
+
+	var keyNotFound *KeyNotFoundError
+	if _, err := (Pointer{"b", "c"}).Delete(document); !errors.As(err, &keyNotFound) {
+		t.Errorf("expected a KeyNotFoundError got %v", err)
+	}
+	if _, err := (Pointer{}).Delete(document); err == nil {
+		t.Errorf("expected an error deleting the document")
+	}
+}
@@ -0,0 +1,348 @@
+package JSONScanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const lineSeparator = '\u2028'
+const paragraphSeparator = '\u2029'
+
+// lineContinuation is returned for a backslash followed by a line terminator, which adds nothing to the string
+const lineContinuation = rune(-1)
+
+// tokenizeJSON5 handles the tokens that only exist in JSON5 or are read differently,
+// ok is false when b starts a token shared with json.
+func (lexer *JSONLexer) tokenizeJSON5(b byte) (token *Token, ok bool, err error) {
+	switch {
+	case b == '\'':
+		token, err = lexer.tokenizeString('\'')
+		return token, true, err
+	case b == '+' || b == '-' || b == '.' || isDigit(b):
+		token, err = lexer.tokenizeJSON5Number()
+		return token, true, err
+	case b == '\\' || b == '$' || b == '_' || (b|0x20 >= 'a' && b|0x20 <= 'z'):
+		token, err = lexer.tokenizeIdentifier()
+		return token, true, err
+	case b >= utf8.RuneSelf:
+		lexer.fill(utf8.UTFMax - 2)
+		r, _ := utf8.DecodeRune(lexer.buf[lexer.pos-1:])
+		if isIdentifierStart(r) {
+			token, err = lexer.tokenizeIdentifier()
+			return token, true, err
+		}
+	}
+	return nil, false, nil
+}
+
+// json5Whitespace consumes the rest of the whitespace started by b that only JSON5 accepts
+func (lexer *JSONLexer) json5Whitespace(b byte) bool {
+	if b == '\v' || b == '\f' {
+		return true
+	}
+	if b < utf8.RuneSelf {
+		return false
+	}
+
+	lexer.fill(utf8.UTFMax - 2)
+	r, size := utf8.DecodeRune(lexer.buf[lexer.pos-1:])
+	switch {
+	case r == lineSeparator || r == paragraphSeparator:
+		lexer.pos += size - 1
+		lexer.Line++
+		lexer.Column = 0
+		return true
+	case r == '\u00a0' || r == '\ufeff' || unicode.Is(unicode.Zs, r):
+		lexer.pos += size - 1
+		return true
+	}
+	return false
+}
+
+func isIdentifierStart(r rune) bool {
+	return r == '$' || r == '_' || unicode.IsLetter(r) || unicode.Is(unicode.Nl, r)
+}
+
+func isIdentifierPart(r rune) bool {
+	return isIdentifierStart(r) ||
+		unicode.In(r, unicode.Mn, unicode.Mc, unicode.Nd, unicode.Pc) ||
+		r == '\u200c' || r == '\u200d'
+}
+
+// tokenizeIdentifier reads an ECMAScript IdentifierName, \uXXXX escapes included
+func (lexer *JSONLexer) tokenizeIdentifier() (*Token, error) {
+	line := lexer.Line
+	col := lexer.Column
+	offset := lexer.Offset() - 1
+
+	// read the first character again with the rest
+	lexer.pos--
+	lexer.Column--
+	lexer.start = lexer.pos
+	lexer.scratch = lexer.scratch[:0]
+
+	for !lexer.eof(0) {
+		b := lexer.buf[lexer.pos]
+		first := len(lexer.scratch) == 0
+
+		if b == '\\' {
+			if lexer.peekNextByte(1) != 'u' {
+				lexer.pos += 2
+				lexer.Column += 2
+				return nil, fmt.Errorf("invalid escape in identifier")
+			}
+			lexer.pos += 2
+			lexer.Column += 2
+			r, err := lexer.tokenizeHex(4)
+			if err != nil {
+				return nil, err
+			}
+			if (first && !isIdentifierStart(r)) || !isIdentifierPart(r) {
+				return nil, fmt.Errorf("invalid character %q in identifier", r)
+			}
+			lexer.scratch = utf8.AppendRune(lexer.scratch, r)
+			continue
+		}
+
+		r, size := rune(b), 1
+		if b >= utf8.RuneSelf {
+			lexer.fill(utf8.UTFMax - 1)
+			r, size = utf8.DecodeRune(lexer.buf[lexer.pos:])
+		}
+		if (first && !isIdentifierStart(r)) || !isIdentifierPart(r) {
+			break
+		}
+		lexer.scratch = append(lexer.scratch, lexer.buf[lexer.pos:lexer.pos+size]...)
+		lexer.pos += size
+		lexer.Column++
+	}
+
+	if len(lexer.scratch) == 0 {
+		lexer.pos++
+		lexer.Column++
+		return nil, fmt.Errorf("unrecognised character %c", lexer.buf[lexer.pos-1])
+	}
+
+	return &Token{
+		Type:   Identifier,
+		Value:  string(lexer.scratch),
+		Line:   line,
+		Column: col,
+		Offset: offset,
+	}, nil
+}
+
+// tokenizeJSON5Escape reads the escapes JSON5 adds to the json ones, b is the character after the backslash
+func (lexer *JSONLexer) tokenizeJSON5Escape(b byte) (rune, error) {
+	switch b {
+	case '\'':
+		return '\'', nil
+	case 'v':
+		return '\v', nil
+	case '0':
+		if isDigit(lexer.peekNextByte(0)) {
+			return 0, fmt.Errorf("invalid character in string escape code")
+		}
+		return 0, nil
+	case 'x':
+		return lexer.tokenizeHex(2)
+	case newline:
+		lexer.Line++
+		lexer.Column = 0
+		return lineContinuation, nil
+	case whitespace2:
+		if lexer.peekNextByte(0) == newline {
+			lexer.pos++
+		}
+		lexer.Line++
+		lexer.Column = 0
+		return lineContinuation, nil
+	}
+
+	if b >= '1' && b <= '9' {
+		return 0, fmt.Errorf("invalid character in string escape code")
+	}
+	if b < utf8.RuneSelf {
+		return rune(b), nil
+	}
+
+	// any other character escapes to itself
+	lexer.fill(utf8.UTFMax - 2)
+	r, size := utf8.DecodeRune(lexer.buf[lexer.pos-1:])
+	lexer.pos += size - 1
+	if r == lineSeparator || r == paragraphSeparator {
+		lexer.Line++
+		lexer.Column = 0
+		return lineContinuation, nil
+	}
+	return r, nil
+}
+
+func (lexer *JSONLexer) isHexDigit(lookahead int) bool {
+	_, ok := hexValue(lexer.peekNextByte(lookahead))
+	return ok
+}
+
+// matchWord consumes word if it is next in the input and not followed by more identifier characters
+func (lexer *JSONLexer) matchWord(word string) bool {
+	for i := 0; i < len(word); i++ {
+		if lexer.peekNextByte(i) != word[i] {
+			return false
+		}
+	}
+	if next := lexer.peekNextByte(len(word)); next < utf8.RuneSelf && isIdentifierPart(rune(next)) {
+		return false
+	}
+	lexer.pos += len(word)
+	lexer.Column += len(word)
+	return true
+}
+
+// tokenizeJSON5Number reads the signed, hex, dotted, Infinity and NaN numbers.
+// With NumbersAsLiteral the text is normalized to a valid json number,
+// Infinity and NaN are always float64.
+func (lexer *JSONLexer) tokenizeJSON5Number() (*Token, error) {
+	line := lexer.Line
+	col := lexer.Column
+	offset := lexer.Offset() - 1
+
+	// read the first character again with the rest
+	lexer.pos--
+	lexer.Column--
+	lexer.start = lexer.pos
+
+	sign := ""
+	if b := lexer.peekNextByte(0); b == '+' || b == '-' {
+		if b == '-' {
+			sign = "-"
+		}
+		lexer.pos++
+		lexer.Column++
+	}
+
+	token := &Token{Type: Number, Line: line, Column: col, Offset: offset}
+	invalid := func() (*Token, error) {
+		return nil, fmt.Errorf("invalid number Literal %s", lexer.lexeme())
+	}
+
+	if lexer.matchWord("Infinity") {
+		if sign == "-" {
+			token.Value = math.Inf(-1)
+		} else {
+			token.Value = math.Inf(1)
+		}
+		return token, nil
+	}
+	if lexer.matchWord("NaN") {
+		token.Value = math.NaN()
+		return token, nil
+	}
+
+	if lexer.peekNextByte(0) == '0' && (lexer.peekNextByte(1) == 'x' || lexer.peekNextByte(1) == 'X') {
+		lexer.pos += 2
+		lexer.Column += 2
+		digitsStart := lexer.pos
+		for lexer.isHexDigit(0) {
+			lexer.pos++
+			lexer.Column++
+		}
+		if lexer.pos == digitsStart || lexer.identifierFollows() {
+			return invalid()
+		}
+		n, _ := new(big.Int).SetString(sign+string(lexer.buf[digitsStart:lexer.pos]), 16)
+		token.Value = lexer.integerValue(n.String())
+		return token, nil
+	}
+
+	integerDigits := 0
+	if lexer.peekNextByte(0) == '0' {
+		lexer.pos++
+		lexer.Column++
+		integerDigits = 1
+	} else {
+		integerDigits = lexer.tokenizeDigitOnly()
+	}
+
+	integral := true
+	fractionDigits := 0
+	if lexer.peekNextByte(0) == '.' {
+		integral = false
+		lexer.pos++
+		lexer.Column++
+		fractionDigits = lexer.tokenizeDigitOnly()
+	}
+	if integerDigits == 0 && fractionDigits == 0 {
+		return invalid()
+	}
+
+	if e := lexer.peekNextByte(0); e == 'e' || e == 'E' {
+		integral = false
+		lexer.pos++
+		lexer.Column++
+		if sign := lexer.peekNextByte(0); sign == '+' || sign == '-' {
+			lexer.pos++
+			lexer.Column++
+		}
+		if lexer.tokenizeDigitOnly() == 0 {
+			return invalid()
+		}
+	}
+
+	if lexer.identifierFollows() {
+		return invalid()
+	}
+
+	// normalize +1, .5 and 5. to the json 1, 0.5 and 5
+	text := strings.TrimPrefix(string(lexer.lexeme()), "+")
+	if integerDigits == 0 {
+		text = strings.Replace(text, ".", "0.", 1)
+	}
+	if !integral && fractionDigits == 0 {
+		text = strings.Replace(text, ".", "", 1)
+	}
+
+	if integral {
+		token.Value = lexer.integerValue(text)
+		return token, nil
+	}
+	if lexer.Numbers == NumbersAsLiteral {
+		token.Value = json.Number(text)
+		return token, nil
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, err
+	}
+	token.Value = f
+	return token, nil
+}
+
+// identifierFollows consumes the next character if it starts an identifier,
+// like in javascript a number can't be directly followed by one
+func (lexer *JSONLexer) identifierFollows() bool {
+	if next := lexer.peekNextByte(0); next < utf8.RuneSelf && isIdentifierStart(rune(next)) {
+		lexer.pos++
+		lexer.Column++
+		return true
+	}
+	return false
+}
+
+// integerValue converts the decimal text of an integer according to the number mode
+func (lexer *JSONLexer) integerValue(text string) interface{} {
+	switch lexer.Numbers {
+	case NumbersAsLiteral:
+		return json.Number(text)
+	case NumbersAsInt64:
+		return parseInteger(text)
+	default:
+		f, _ := strconv.ParseFloat(text, 64)
+		return f
+	}
+}
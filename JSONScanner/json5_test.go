@@ -0,0 +1,97 @@
+package JSONScanner
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestJSON5Tokens(t *testing.T) {
+	input := "{ key: 'single \"quoted\"', $_ün\\u0069: +.5e1, h: -0xFF, t: 5.,   l: 'a\\\nb', i: -Infinity }"
+
+	jsonLexer := JSONLexer{Line: 1, Column: 0, JSON5: true}
+	jsonLexer.ReadJson([]byte(input))
+
+	expected := []struct {
+		tType int
+		value interface{}
+	}{
+		{LeftBracket, "{"},
+		{Identifier, "key"}, {Colon, ":"}, {String, `single "quoted"`}, {Comma, ","},
+		{Identifier, "$_üni"}, {Colon, ":"}, {Number, float64(5)}, {Comma, ","},
+		{Identifier, "h"}, {Colon, ":"}, {Number, float64(-255)}, {Comma, ","},
+		{Identifier, "t"}, {Colon, ":"}, {Number, float64(5)}, {Comma, ","},
+		{Identifier, "l"}, {Colon, ":"}, {String, "ab"}, {Comma, ","},
+		{Identifier, "i"}, {Colon, ":"}, {Number, math.Inf(-1)},
+		{RightBracket, "}"},
+		{EOF, "EOF"},
+	}
+
+	for _, want := range expected {
+		token, err := jsonLexer.GetNextToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.Type != want.tType || token.Value != want.value {
+			t.Errorf("expected %v, got %v", want.value, token.Value)
+		}
+	}
+	if jsonLexer.Line != 2 {
+		t.Errorf("expected the line continuation to count a line, got Line %d", jsonLexer.Line)
+	}
+}
+
+func TestJSON5NumbersAsLiteral(t *testing.T) {
+	cases := map[string]json.Number{
+		"+1":     "1",
+		".5":     "0.5",
+		"-.5e2":  "-0.5e2",
+		"5.":     "5",
+		"5.e3":   "5e3",
+		"0x1F":   "31",
+		"-0XfF":  "-255",
+		"12.50":  "12.50",
+		"+0.0E0": "0.0E0",
+	}
+
+	for input, expected := range cases {
+		jsonLexer := JSONLexer{Line: 1, Column: 0, JSON5: true, Numbers: NumbersAsLiteral}
+		jsonLexer.ReadJson([]byte(input))
+		token, err := jsonLexer.GetNextToken()
+		if err != nil {
+			t.Errorf("%s: %s", input, err)
+			continue
+		}
+		if token.Value != expected {
+			t.Errorf("%s: expected %s, got %v", input, expected, token.Value)
+		}
+	}
+}
+
+func TestJSON5InvalidTokens(t *testing.T) {
+	cases := []string{
+		"0x",
+		"+",
+		"-.",
+		".e1",
+		"1e",
+		"+Infinityx",
+		"'unterminated",
+		"'line\nbreak'",
+		`'\1'`,
+		`'\01'`,
+		`'\xZ0'`,
+		`1abc`,
+		`0x1Fg`,
+		`a\x`,
+		"#",
+	}
+
+	for _, input := range cases {
+		jsonLexer := JSONLexer{Line: 1, Column: 0, JSON5: true}
+		jsonLexer.ReadJson([]byte(input))
+		if token, err := jsonLexer.GetNextToken(); err == nil {
+			t.Errorf("%q: expected an error, got %v", input, token.Value)
+		}
+	}
+}
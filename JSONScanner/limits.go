@@ -0,0 +1,48 @@
+package JSONScanner
+
+import "fmt"
+
+// Limits guards against oversized untrusted input, a zero field is not limited.
+// The scanner enforces MaxInputSize, MaxStringLength and MaxTokens,
+// MaxMembers and MaxElements are enforced by the parser.
+type Limits struct {
+	// MaxInputSize is the most bytes of input, a []byte input larger than that fails before being scanned
+	MaxInputSize int
+	// MaxStringLength is the most bytes a string can take in the input, quotes excluded
+	MaxStringLength int
+	// MaxTokens is the most tokens returned by GetNextToken, EOF excluded
+	MaxTokens int
+	// MaxMembers is the most members of a single object
+	MaxMembers int
+	// MaxElements is the most elements of a single array
+	MaxElements int
+}
+
+// LimitError reports an input exceeding one of the limits
+type LimitError struct {
+	// Limit is the name of the field holding the limit, like MaxTokens
+	Limit string
+	Max   int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s of %d exceeded", e.Limit, e.Max)
+}
+
+// limitInput stops reading at MaxInputSize,
+// the bytes past the limit are dropped and the reader is considered failed with a *LimitError
+func (lexer *JSONLexer) limitInput() {
+	maxSize := lexer.Limits.MaxInputSize
+	if maxSize > 0 && lexer.base+len(lexer.buf) > maxSize {
+		lexer.buf = lexer.buf[:maxSize-lexer.base]
+		lexer.readErr = &LimitError{Limit: "MaxInputSize", Max: maxSize}
+	}
+}
+
+// limitErr returns the *LimitError that stopped reading the input
+func (lexer *JSONLexer) limitErr() error {
+	if err, ok := lexer.readErr.(*LimitError); ok {
+		return err
+	}
+	return nil
+}
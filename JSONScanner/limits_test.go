@@ -0,0 +1,91 @@
+package JSONScanner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// scanAll returns the types of the tokens scanned before EOF or the first error
+func scanAll(lexer *JSONLexer) ([]int, error) {
+	var types []int
+	for {
+		token, err := lexer.GetNextToken()
+		if err != nil {
+			return types, err
+		}
+		if token.Type == EOF {
+			return types, nil
+		}
+		types = append(types, token.Type)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	cases := []struct {
+		input  string
+		limits Limits
+		// tokens is the number of tokens scanned before the limit error
+		tokens int
+		limit  string
+	}{
+		{`[1, 2]`, Limits{MaxInputSize: 6}, 5, ""},
+		{`[1, 2] `, Limits{MaxInputSize: 6}, 0, "MaxInputSize"},
+		{`"abc"`, Limits{MaxStringLength: 3}, 1, ""},
+		{`["abcd"]`, Limits{MaxStringLength: 3}, 1, "MaxStringLength"},
+		{`"\u0041"`, Limits{MaxStringLength: 3}, 0, "MaxStringLength"},
+		{`{"a": [1, 2]}`, Limits{MaxTokens: 9}, 9, ""},
+		{`{"a": [1, 2]}`, Limits{MaxTokens: 8}, 8, "MaxTokens"},
+	}
+
+	for _, c := range cases {
+		lexer := &JSONLexer{Line: 1, Limits: c.limits}
+		lexer.ReadJson([]byte(c.input))
+		types, err := scanAll(lexer)
+		checkLimit(t, c.input, types, err, c.tokens, c.limit)
+	}
+}
+
+func TestStreamingLimits(t *testing.T) {
+	input := `[1, 2]  `
+	lexer := NewJSONLexer(iotest.OneByteReader(strings.NewReader(input)))
+	lexer.Limits = Limits{MaxInputSize: 6}
+	types, err := scanAll(lexer)
+	checkLimit(t, input, types, err, 5, "MaxInputSize")
+	if lexer.Offset() != 6 {
+		t.Errorf("expected the lexer to stop at offset 6 got %d", lexer.Offset())
+	}
+
+	// the number could continue past the limit
+	input = `[1, 23]`
+	lexer = NewJSONLexer(iotest.OneByteReader(strings.NewReader(input)))
+	lexer.Limits = Limits{MaxInputSize: 5}
+	types, err = scanAll(lexer)
+	checkLimit(t, input, types, err, 3, "MaxInputSize")
+
+	lexer = NewJSONLexer(strings.NewReader(`"` + strings.Repeat("a", 100000) + `"`))
+	lexer.Limits = Limits{MaxStringLength: 10}
+	types, err = scanAll(lexer)
+	checkLimit(t, "long string", types, err, 0, "MaxStringLength")
+	if lexer.Offset() > 2*readBufferSize {
+		t.Errorf("expected the lexer to stop early got offset %d", lexer.Offset())
+	}
+}
+
+func checkLimit(t *testing.T, input string, types []int, err error, tokens int, limit string) {
+	t.Helper()
+	if len(types) != tokens {
+		t.Errorf("%s: expected %d tokens got %v", input, tokens, types)
+	}
+	if limit == "" {
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", input, err)
+		}
+		return
+	}
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) || limitErr.Limit != limit {
+		t.Errorf("%s: expected a %s LimitError got %v", input, limit, err)
+	}
+}
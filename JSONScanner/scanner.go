@@ -1,11 +1,12 @@
 package JSONScanner
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"strconv"
-	"strings"
-	"unicode"
+	"unicode/utf16"
 	"unicode/utf8"
 )
 
@@ -21,144 +22,315 @@ const (
 	Number
 	EOF
 	Minus
+	// Comment tokens are only produced when both AllowComments and EmitComments are set
+	Comment
+	// Identifier tokens are the unquoted names of JSON5, true, false, null, Infinity and NaN included
+	Identifier
 )
 
-const startChar = rune('\u0020')
-const endChar = rune('\U0010FFFF')
+// NumberMode selects the go type of the Number token values
+type NumberMode int
 
-const startCapitalLetter = rune('A')
-const endCapitalHexLetter = rune('F')
-const startLowercaseLetter = rune('a')
-const endLowercaseHexLetter = rune('f')
-const endLowercaseLetter = rune('z')
+const (
+	NumbersAsFloat64 NumberMode = iota
+	// NumbersAsLiteral keeps the exact source text of the number as a json.Number
+	NumbersAsLiteral
+	// NumbersAsInt64 returns the numbers without fraction and exponent as int64,
+	// or as *big.Int when they overflow, the rest stay float64
+	NumbersAsInt64
+)
+
+const whitespace1 = ' '
+const newline = '\u000A'
+const whitespace2 = '\u000D'
+const whitespace3 = '\u0009'
 
-const whitespace1 = rune('\u0020')
-const newline = rune('\u000A')
-const whitespace2 = rune('\u000D')
-const whitespace3 = rune('\u0009')
+// readBufferSize is the initial number of bytes kept in memory when the lexer reads from an io.Reader
+const readBufferSize = 4096
 
 type Token struct {
 	Type         int
 	Value        interface{}
 	Line, Column int
+	// Offset is the byte offset of the first character of the token in the whole input
+	Offset int
 }
 
+// JSONLexer produces tokens scanning the utf-8 bytes of the input directly,
+// multi-byte sequences are only decoded inside strings.
+// The input is either loaded whole with ReadJson or streamed with NewJSONLexer.
+// Line and Column point to the last character consumed,
+// which is the offending character when GetNextToken returns an error.
 type JSONLexer struct {
-	Runes        []rune
-	Position     int
 	Line, Column int
-	strBuilder   strings.Builder
+	Numbers      NumberMode
+	// AllowComments skips the // line and /* block */ comments like JSONC
+	AllowComments bool
+	// EmitComments returns the skipped comments as Comment tokens
+	EmitComments bool
+	// JSON5 accepts the JSON5 extensions: identifiers, single quoted and multi-line strings,
+	// hex, signed and dotted numbers, Infinity, NaN, comments and extra whitespace
+	JSON5 bool
+	// Limits bounds the size of the input, its strings and its number of tokens
+	Limits Limits
+	buf    []byte
+	// pos is the next byte to read in buf
+	pos int
+	// start is the first byte of the token being scanned, fill never discards it
+	start int
+	// base is the offset of buf[0] in the whole input
+	base    int
+	reader  io.Reader
+	readErr error
+	scratch []byte
+	// tokens counts the tokens returned for Limits.MaxTokens
+	tokens int
+}
+
+// NewJSONLexer creates a lexer that pulls the input from reader
+// keeping in memory only the token being scanned and a bounded read buffer.
+func NewJSONLexer(reader io.Reader) *JSONLexer {
+	return &JSONLexer{
+		Line:   1,
+		Column: 0,
+		buf:    make([]byte, 0, readBufferSize),
+		reader: reader,
+	}
 }
 
 func (lexer *JSONLexer) ReadJson(jsonBytes []byte) {
-	lexer.Runes = []rune(string(jsonBytes))
+	lexer.buf = jsonBytes
+	lexer.pos = 0
+	lexer.start = 0
+	lexer.base = 0
+	lexer.reader = nil
+	lexer.readErr = nil
+	lexer.tokens = 0
 }
 
-func (lexer *JSONLexer) jump(ahead int) error {
-	if lexer.eof(ahead) {
-		return io.EOF
+// Offset returns the byte offset of the next unread byte in the whole input
+func (lexer *JSONLexer) Offset() int {
+	return lexer.base + lexer.pos
+}
+
+// fill reads from the reader until the byte at pos+lookahead is loaded,
+// it returns false when the input ends before that.
+func (lexer *JSONLexer) fill(lookahead int) bool {
+	for lexer.pos+lookahead >= len(lexer.buf) {
+		if lexer.reader == nil || lexer.readErr != nil {
+			return false
+		}
+
+		// drop what is already consumed before growing the buffer
+		if lexer.start > 0 {
+			n := copy(lexer.buf, lexer.buf[lexer.start:])
+			lexer.buf = lexer.buf[:n]
+			lexer.base += lexer.start
+			lexer.pos -= lexer.start
+			lexer.start = 0
+		}
+
+		if len(lexer.buf) == cap(lexer.buf) {
+			grown := make([]byte, len(lexer.buf), 2*cap(lexer.buf)+readBufferSize)
+			copy(grown, lexer.buf)
+			lexer.buf = grown
+		}
+
+		n, err := lexer.reader.Read(lexer.buf[len(lexer.buf):cap(lexer.buf)])
+		lexer.buf = lexer.buf[:len(lexer.buf)+n]
+		if err != nil {
+			lexer.readErr = err
+		}
+		lexer.limitInput()
 	}
-	lexer.Column += ahead
-	lexer.Position += ahead
-	return nil
+	return true
 }
 
 func (lexer *JSONLexer) eof(lookahead int) bool {
-	return lexer.Position+lookahead > len(lexer.Runes)-1
+	if lexer.pos+lookahead < len(lexer.buf) {
+		return false
+	}
+	return !lexer.fill(lookahead)
 }
 
-func (lexer *JSONLexer) getNextRune() (rune, error) {
-	if lexer.eof(0) {
-		return 0, io.EOF
+// err returns the error that stopped the reader, io.EOF when the input is exhausted
+func (lexer *JSONLexer) err() error {
+	if lexer.readErr != nil {
+		return lexer.readErr
 	}
-
-	lexer.Position++
-
-	return lexer.Runes[lexer.Position-1], nil
+	return io.EOF
 }
 
-func (lexer *JSONLexer) peekNextRune(lookahead int) rune {
+func (lexer *JSONLexer) peekNextByte(lookahead int) byte {
 	if lexer.eof(lookahead) {
 		return 0
 	}
-	return lexer.Runes[lexer.Position+lookahead]
+	return lexer.buf[lexer.pos+lookahead]
 }
 
-func (lexer *JSONLexer) isHex(lookahead int) bool {
-	lookaheadRune := lexer.peekNextRune(lookahead)
-	if unicode.IsDigit(lookaheadRune) {
-		return true
+func (lexer *JSONLexer) getNextByte() (byte, error) {
+	if lexer.eof(0) {
+		return 0, lexer.err()
 	}
-	return (lookaheadRune >= startLowercaseLetter && lookaheadRune <= endLowercaseHexLetter) ||
-		(lookaheadRune >= startCapitalLetter && lookaheadRune <= endCapitalHexLetter)
+	lexer.pos++
+	lexer.Column++
+	return lexer.buf[lexer.pos-1], nil
+}
+
+// lexeme returns the bytes of the token being scanned
+func (lexer *JSONLexer) lexeme() []byte {
+	return lexer.buf[lexer.start:lexer.pos]
 }
 
-func (lexer *JSONLexer) tokenizeDigitOnly() (string, error) {
-	lookahead := 0
-	var digitBuilder strings.Builder
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
 
-	for {
-		if unicode.IsDigit(lexer.peekNextRune(lookahead)) == false {
-			break
-		}
-		r, err := lexer.getNextRune()
-		if err != nil {
-			return "", err
-		}
-		digitBuilder.WriteRune(r)
+func hexValue(b byte) (rune, bool) {
+	switch {
+	case isDigit(b):
+		return rune(b - '0'), true
+	case b >= 'a' && b <= 'f':
+		return rune(b-'a') + 10, true
+	case b >= 'A' && b <= 'F':
+		return rune(b-'A') + 10, true
 	}
+	return 0, false
+}
 
-	return digitBuilder.String(), nil
+func (lexer *JSONLexer) tokenizeDigitOnly() int {
+	count := 0
+	for isDigit(lexer.peekNextByte(0)) {
+		lexer.pos++
+		lexer.Column++
+		count++
+	}
+	return count
 }
 
-func (lexer *JSONLexer) tokenizeString() (*Token, error) {
-	defer lexer.strBuilder.Reset()
+func (lexer *JSONLexer) tokenizeString(quote byte) (*Token, error) {
+	line := lexer.Line
+	col := lexer.Column
+	offset := lexer.Offset() - 1
+	// the opening quote is not part of the value
+	lexer.start = lexer.pos
+	escaped := false
+	lexer.scratch = lexer.scratch[:0]
 
 	for {
-		r, err := lexer.getNextRune()
-
-		if err != nil {
-			return nil, err
+		if maxLength := lexer.Limits.MaxStringLength; maxLength > 0 && lexer.pos-lexer.start > maxLength {
+			return nil, &LimitError{Limit: "MaxStringLength", Max: maxLength}
 		}
+		if lexer.eof(0) {
+			return nil, fmt.Errorf("unexpected end of json in string starting at Line %d, col %d", line, col)
+		}
+		b := lexer.buf[lexer.pos]
 
-		if r < startChar || r > endChar {
+		if b == quote {
 			break
 		}
 
-		if r == '"' {
-			break
-		} else if r == '\\' {
-			// next character
-			escaped, err := lexer.tokenizeEscapedCharacters()
-			if err != nil {
-				return &Token{}, err
+		// fast path for the plain ascii characters already in the buffer
+		if !escaped && b >= whitespace1 && b < utf8.RuneSelf && b != '\\' {
+			i := lexer.pos + 1
+			for i < len(lexer.buf) {
+				c := lexer.buf[i]
+				if c == quote || c == '\\' || c < whitespace1 || c >= utf8.RuneSelf {
+					break
+				}
+				i++
 			}
-			lexer.strBuilder.WriteRune(escaped)
-		} else {
-			lexer.strBuilder.WriteRune(r)
+			lexer.Column += i - lexer.pos
+			lexer.pos = i
+			continue
 		}
 
+		if b < utf8.RuneSelf {
+			lexer.pos++
+			lexer.Column++
+			// JSON5 only forbids the line terminators
+			if b < whitespace1 && (!lexer.JSON5 || b == newline || b == whitespace2) {
+				return nil, fmt.Errorf("invalid character %q in string", b)
+			}
+			if b == '\\' {
+				if !escaped {
+					lexer.scratch = append(lexer.scratch, lexer.buf[lexer.start:lexer.pos-1]...)
+					escaped = true
+				}
+				// a truncated escape is an unterminated string, not the io.EOF of a clean end of input
+				if lexer.eof(0) && lexer.err() == io.EOF {
+					return nil, fmt.Errorf("unexpected end of json in string starting at Line %d, col %d", line, col)
+				}
+				r, err := lexer.tokenizeEscapedCharacters()
+				if err != nil {
+					return nil, err
+				}
+				if r != lineContinuation {
+					lexer.scratch = utf8.AppendRune(lexer.scratch, r)
+				}
+			} else if escaped {
+				lexer.scratch = append(lexer.scratch, b)
+			}
+			continue
+		}
+
+		// make sure a multi-byte sequence is not split at the end of the buffer
+		lexer.fill(utf8.UTFMax - 1)
+		r, size := utf8.DecodeRune(lexer.buf[lexer.pos:])
+		if r == utf8.RuneError && size == 1 && !escaped {
+			lexer.scratch = append(lexer.scratch, lexer.buf[lexer.start:lexer.pos]...)
+			escaped = true
+		}
+		if escaped {
+			lexer.scratch = utf8.AppendRune(lexer.scratch, r)
+		}
+		lexer.pos += size
+		lexer.Column++
 	}
-	value := lexer.strBuilder.String()
-	line := lexer.Line
-	col := lexer.Column + 1
+
+	var value string
+	if escaped {
+		value = string(lexer.scratch)
+	} else {
+		value = string(lexer.lexeme())
+	}
+
 	// count the ending double quote
-	lexer.Column += utf8.RuneCountInString(value) + 1
+	lexer.pos++
+	lexer.Column++
+
 	return &Token{
 		Type:   String,
 		Value:  value,
 		Line:   line,
 		Column: col,
+		Offset: offset,
 	}, nil
+}
 
+func (lexer *JSONLexer) tokenizeHex(digits int) (rune, error) {
+	var value rune
+	for i := 0; i < digits; i++ {
+		digit, ok := hexValue(lexer.peekNextByte(i))
+		if !ok {
+			// point to the invalid character
+			lexer.pos += i + 1
+			lexer.Column += i + 1
+			return 0, fmt.Errorf("invalid character in string escape code")
+		}
+		value = value<<4 | digit
+	}
+	lexer.pos += digits
+	lexer.Column += digits
+	return value, nil
 }
 
 func (lexer *JSONLexer) tokenizeEscapedCharacters() (rune, error) {
-	r, err := lexer.getNextRune()
+	b, err := lexer.getNextByte()
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
-	switch r {
+	switch b {
 	case '"':
 		return '"', nil
 	case '\\':
@@ -176,279 +348,328 @@ func (lexer *JSONLexer) tokenizeEscapedCharacters() (rune, error) {
 	case 't':
 		return '\t', nil
 	case 'u':
-
-		lookahead1, lookahead2, lookahead3, lookahead4 := 0, 1, 2, 3
-
-		if lexer.isHex(lookahead1) && lexer.isHex(lookahead2) && lexer.isHex(lookahead3) && lexer.isHex(lookahead4) {
-			hex1 := string(lexer.peekNextRune(lookahead1))
-			hex2 := string(lexer.peekNextRune(lookahead2))
-			hex3 := string(lexer.peekNextRune(lookahead3))
-			hex4 := string(lexer.peekNextRune(lookahead4))
-
-			hexValue := fmt.Sprintf("%s%s%s%s", hex1, hex2, hex3, hex4)
-			unicodePoint, err := strconv.ParseUint(hexValue, 16, 32)
-
+		r, err := lexer.tokenizeHex(4)
+		if err != nil {
+			return 0, err
+		}
+		if !utf16.IsSurrogate(r) {
+			return r, nil
+		}
+		// a surrogate pair is written as two consecutive escapes
+		if lexer.peekNextByte(0) == '\\' && lexer.peekNextByte(1) == 'u' {
+			lexer.pos += 2
+			lexer.Column += 2
+			r2, err := lexer.tokenizeHex(4)
 			if err != nil {
 				return 0, err
 			}
-			err = lexer.jump(4)
-
-			if err != nil {
-				return 0, err
+			if combined := utf16.DecodeRune(r, r2); combined != utf8.RuneError {
+				return combined, nil
 			}
-
-			return rune(unicodePoint), nil
+			// not a pair, keep the second escape for the next iteration
+			lexer.pos -= 6
+			lexer.Column -= 6
 		}
+		return utf8.RuneError, nil
+	}
+	if lexer.JSON5 {
+		return lexer.tokenizeJSON5Escape(b)
 	}
 	return 0, fmt.Errorf("invalid character in string escape code")
 }
 
-func (lexer *JSONLexer) tokenizeDigits(digitAlreadyRead rune) (*Token, error) {
-	defer lexer.strBuilder.Reset()
+func (lexer *JSONLexer) tokenizeDigits() (*Token, error) {
+	line := lexer.Line
+	col := lexer.Column
+	offset := lexer.Offset() - 1
 
-	if digitAlreadyRead == '-' {
-		if lexer.peekNextRune(0) == '0' &&
-			lexer.peekNextRune(1) != 'e' &&
-			lexer.peekNextRune(1) != 'E' && lexer.peekNextRune(1) != '.' {
-			_, err := lexer.getNextRune()
-			if err != nil {
-				return nil, err
-			}
-			lexer.Column++
-			return &Token{
-				Type:   Number,
-				Value:  float64(-0),
-				Line:   lexer.Line,
-				Column: lexer.Column,
-			}, nil
-		}
-	}
+	// the first digit or minus is already consumed
+	lexer.start = lexer.pos - 1
 
-	if digitAlreadyRead == '0' {
-		if lexer.peekNextRune(0) != 'e' && lexer.peekNextRune(0) != 'E' && lexer.peekNextRune(0) != '.' {
-			return &Token{
-				Type:   Number,
-				Value:  float64(0),
-				Line:   lexer.Line,
-				Column: lexer.Column,
-			}, nil
+	if lexer.buf[lexer.start] == '-' {
+		if !isDigit(lexer.peekNextByte(0)) {
+			return nil, fmt.Errorf("invalid number Literal %s", lexer.lexeme())
 		}
+		lexer.pos++
+		lexer.Column++
 	}
 
-	lexer.strBuilder.WriteRune(digitAlreadyRead)
-	digits, err := lexer.tokenizeDigitOnly()
-
-	if err != nil {
-		return nil, err
+	// leading zeros are not allowed, the following digits belong to the next token
+	if lexer.buf[lexer.pos-1] != '0' {
+		lexer.tokenizeDigitOnly()
 	}
-
-	lexer.strBuilder.WriteString(digits)
+	integral := true
 
 	// real
-	if lexer.peekNextRune(0) == '.' && unicode.IsDigit(lexer.peekNextRune(1)) {
-		r, err := lexer.getNextRune()
-		if err != nil {
-			return nil, err
+	if lexer.peekNextByte(0) == '.' {
+		integral = false
+		lexer.pos++
+		lexer.Column++
+		if lexer.tokenizeDigitOnly() == 0 {
+			return nil, fmt.Errorf("invalid number Literal %s", lexer.lexeme())
 		}
+	}
 
-		lexer.strBuilder.WriteRune(r)
-
-		digits, err := lexer.tokenizeDigitOnly()
-		if err != nil {
-			return nil, err
+	// exponent
+	if e := lexer.peekNextByte(0); e == 'e' || e == 'E' {
+		integral = false
+		lexer.pos++
+		lexer.Column++
+		if sign := lexer.peekNextByte(0); sign == '+' || sign == '-' {
+			lexer.pos++
+			lexer.Column++
+		}
+		if lexer.tokenizeDigitOnly() == 0 {
+			return nil, fmt.Errorf("invalid number Literal %s", lexer.lexeme())
 		}
-		lexer.strBuilder.WriteString(digits)
 	}
 
-	// exponent
-	if lexer.peekNextRune(0) == 'E' || lexer.peekNextRune(0) == 'e' {
-		e, err := lexer.getNextRune()
+	var value interface{}
+	if lexer.Numbers == NumbersAsLiteral {
+		value = json.Number(lexer.lexeme())
+	} else if lexer.Numbers == NumbersAsInt64 && integral {
+		value = parseInteger(string(lexer.lexeme()))
+	} else {
+		f, err := strconv.ParseFloat(string(lexer.lexeme()), 64)
 		if err != nil {
 			return nil, err
 		}
+		value = f
+	}
+
+	return &Token{
+		Type:   Number,
+		Value:  value,
+		Line:   line,
+		Column: col,
+		Offset: offset,
+	}, nil
+}
 
-		lexer.strBuilder.WriteRune(e)
-		lookaheadRune1 := lexer.peekNextRune(0)
-		lookaheadRune2 := lexer.peekNextRune(1)
+// whitespace reports if b, the byte just consumed, is whitespace consuming the rest of a multi-byte JSON5 whitespace
+func (lexer *JSONLexer) whitespace(b byte) bool {
+	switch b {
+	case whitespace1, whitespace2, whitespace3:
+		return true
+	case newline:
+		lexer.Line++
+		lexer.Column = 0
+		return true
+	}
+	if lexer.JSON5 {
+		return lexer.json5Whitespace(b)
+	}
+	return false
+}
 
-		if (lookaheadRune1 == '+' || lookaheadRune1 == '-') && unicode.IsDigit(lookaheadRune2) || unicode.IsDigit(lookaheadRune1) {
-			plusOrMinus, _ := lexer.getNextRune()
-			lexer.strBuilder.WriteRune(plusOrMinus)
+// tokenizeComment reads a // line comment up to the newline or a /* block comment */
+func (lexer *JSONLexer) tokenizeComment() (*Token, error) {
+	line := lexer.Line
+	col := lexer.Column
+	offset := lexer.Offset() - 1
 
-			digits, err := lexer.tokenizeDigitOnly()
+	// the first slash is already consumed
+	lexer.start = lexer.pos - 1
 
-			if err != nil {
-				return nil, err
+	switch lexer.peekNextByte(0) {
+	case '/':
+		for !lexer.eof(0) && lexer.buf[lexer.pos] != newline {
+			lexer.advanceCommentByte()
+		}
+	case '*':
+		lexer.pos++
+		lexer.Column++
+		for {
+			if lexer.eof(1) {
+				return nil, fmt.Errorf("unterminated block comment starting at Line %d, col %d", line, col)
+			}
+			if lexer.buf[lexer.pos] == '*' && lexer.buf[lexer.pos+1] == '/' {
+				lexer.pos += 2
+				lexer.Column += 2
+				break
 			}
-			lexer.strBuilder.WriteString(digits)
-		} else {
-			return nil, fmt.Errorf("invalid number Literal %s", lexer.strBuilder.String())
+			lexer.advanceCommentByte()
 		}
+	default:
+		return nil, fmt.Errorf("unrecognised character /")
 	}
 
-	value, err := strconv.ParseFloat(lexer.strBuilder.String(), 64)
-	if err != nil {
-		return nil, err
+	return &Token{
+		Type:   Comment,
+		Value:  string(lexer.lexeme()),
+		Line:   line,
+		Column: col,
+		Offset: offset,
+	}, nil
+}
+
+// advanceCommentByte consumes one byte of a comment counting the characters and the lines
+func (lexer *JSONLexer) advanceCommentByte() {
+	b := lexer.buf[lexer.pos]
+	lexer.pos++
+	if b == newline {
+		lexer.Line++
+		lexer.Column = 0
+	} else if b&0xC0 != 0x80 {
+		// utf-8 continuation bytes don't start a new character
+		lexer.Column++
 	}
+}
 
-	lexer.strBuilder.Reset()
-	lexer.strBuilder.WriteString(fmt.Sprintf("%v", value))
-	strValue := lexer.strBuilder.String()
+// parseInteger converts a valid integer lexeme to int64 falling back to *big.Int on overflow
+func parseInteger(lexeme string) interface{} {
+	if n, err := strconv.ParseInt(lexeme, 10, 64); err == nil {
+		return n
+	}
+	n, _ := new(big.Int).SetString(lexeme, 10)
+	return n
+}
 
+func (lexer *JSONLexer) tokenizeLiterals() (*Token, error) {
 	line := lexer.Line
 	col := lexer.Column
-	lexer.Column += utf8.RuneCountInString(strValue)
+	offset := lexer.Offset() - 1
+
+	// the first letter is already consumed
+	lexer.start = lexer.pos - 1
+	for b := lexer.peekNextByte(0); b >= 'a' && b <= 'z'; b = lexer.peekNextByte(0) {
+		lexer.pos++
+		lexer.Column++
+	}
+
+	var value interface{}
+
+	switch strVal := string(lexer.lexeme()); strVal {
+	case "null":
+		value = nil
+	case "true":
+		value = true
+	case "false":
+		value = false
+	default:
+		return nil, fmt.Errorf("unrecognised Literal %s", strVal)
+	}
 
 	return &Token{
-		Type:   Number,
+		Type:   Literal,
 		Value:  value,
 		Line:   line,
 		Column: col,
+		Offset: offset,
 	}, nil
-
 }
 
-func (lexer *JSONLexer) tokenizeLiterals(r rune) (*Token, error) {
-	defer lexer.strBuilder.Reset()
+// GetNextToken returns the following token, a token of type EOF once the input is exhausted.
+// A *LimitError is returned when the input goes over one of the Limits.
+func (lexer *JSONLexer) GetNextToken() (*Token, error) {
+	if maxSize := lexer.Limits.MaxInputSize; maxSize > 0 && lexer.reader == nil && len(lexer.buf) > maxSize {
+		return nil, &LimitError{Limit: "MaxInputSize", Max: maxSize}
+	}
 
-	lookahead := 0
-	for r >= startLowercaseLetter && r <= endLowercaseLetter {
-		lexer.strBuilder.WriteRune(r)
+	token, err := lexer.scanToken()
+	// a token ending where the input was cut at MaxInputSize may be incomplete
+	if limitErr := lexer.limitErr(); limitErr != nil && (err != nil || token.Type == EOF || lexer.pos == len(lexer.buf)) {
+		return nil, limitErr
+	}
+	if err != nil {
+		return nil, err
+	}
 
-		if lookahead == 4 {
-			break
+	if token.Type != EOF {
+		lexer.tokens++
+		if maxTokens := lexer.Limits.MaxTokens; maxTokens > 0 && lexer.tokens > maxTokens {
+			return nil, &LimitError{Limit: "MaxTokens", Max: maxTokens}
 		}
-
-		r = lexer.peekNextRune(lookahead)
-		lookahead++
 	}
+	return token, nil
+}
 
-	strVal := lexer.strBuilder.String()
+func (lexer *JSONLexer) scanToken() (*Token, error) {
+	// everything before the next token can be discarded
+	lexer.start = lexer.pos
 
-	if strVal != "null" && strVal != "true" && strVal != "false" {
-		return nil, fmt.Errorf("unrecognised Literal %s", strVal)
-	}
+	b, err := lexer.getNextByte()
 
-	var value interface{}
+	for {
+		for lexer.whitespace(b) {
+			lexer.start = lexer.pos
+			b, err = lexer.getNextByte()
+		}
 
-	if strVal == "null" {
-		value = nil
-	} else {
-		var err error
-		value, err = strconv.ParseBool(strVal)
+		if b != '/' || !(lexer.AllowComments || lexer.JSON5) {
+			break
+		}
+
+		var comment *Token
+		comment, err = lexer.tokenizeComment()
 		if err != nil {
 			return nil, err
 		}
+		if lexer.EmitComments {
+			return comment, nil
+		}
+		lexer.start = lexer.pos
+		b, err = lexer.getNextByte()
 	}
 
-	err := lexer.jump(len(strVal) - 1)
+	if err == io.EOF {
+		return &Token{Type: EOF, Value: "EOF", Line: lexer.Line, Column: lexer.Column, Offset: lexer.Offset()}, nil
+	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &Token{
-		Type:   Literal,
-		Value:  value,
-		Line:   lexer.Line,
-		Column: lexer.Column,
-	}, nil
-}
-
-func (lexer *JSONLexer) GetNextToken() (*Token, error) {
-
-	r, err := lexer.getNextRune()
-	lexer.Column++
-
-	for r == whitespace1 || r == whitespace2 || r == whitespace3 || r == newline {
-		lexer.Column++
-		if r == newline {
-			lexer.Line++
-			lexer.Column = 1
-		}
-		r, err = lexer.getNextRune()
-	}
-
-	if err != nil && err.Error() == "EOF" {
-		return &Token{Type: EOF, Value: "EOF", Line: lexer.Line, Column: lexer.Column}, nil
-	}
-
-	if r == '{' {
-		return &Token{
-			Type:   LeftBracket,
-			Value:  string(r),
-			Line:   lexer.Line,
-			Column: lexer.Column,
-		}, nil
-	}
-	if r == '}' {
-		return &Token{
-			Type:   RightBracket,
-			Value:  string(r),
-			Line:   lexer.Line,
-			Column: lexer.Column,
-		}, nil
-	}
-
-	if r == '[' {
-		return &Token{
-			Type:   LeftSquareBracket,
-			Value:  string(r),
-			Line:   lexer.Line,
-			Column: lexer.Column,
-		}, nil
-	}
-	if r == ']' {
-		return &Token{
-			Type:   RightSquareBracket,
-			Value:  string(r),
-			Line:   lexer.Line,
-			Column: lexer.Column,
-		}, nil
-	}
-
-	if r == ',' {
-		return &Token{
-			Type:   Comma,
-			Value:  string(r),
-			Line:   lexer.Line,
-			Column: lexer.Column,
-		}, nil
-	}
-
-	if r == ':' {
-		return &Token{
-			Type:   Colon,
-			Value:  string(r),
-			Line:   lexer.Line,
-			Column: lexer.Column,
-		}, nil
-	}
-
-	if r == '-' {
-		if unicode.IsDigit(lexer.peekNextRune(0)) {
-			return lexer.tokenizeDigits(r)
+	if lexer.JSON5 {
+		if token, ok, err := lexer.tokenizeJSON5(b); ok {
+			return token, err
 		}
-		return &Token{
-			Type:   Minus,
-			Value:  string(r),
-			Line:   lexer.Line,
-			Column: lexer.Column,
-		}, nil
 	}
 
-	if r == '"' {
-		return lexer.tokenizeString()
+	switch b {
+	case '{':
+		return lexer.punctuation(LeftBracket, "{"), nil
+	case '}':
+		return lexer.punctuation(RightBracket, "}"), nil
+	case '[':
+		return lexer.punctuation(LeftSquareBracket, "["), nil
+	case ']':
+		return lexer.punctuation(RightSquareBracket, "]"), nil
+	case ',':
+		return lexer.punctuation(Comma, ","), nil
+	case ':':
+		return lexer.punctuation(Colon, ":"), nil
+	case '-':
+		if isDigit(lexer.peekNextByte(0)) {
+			return lexer.tokenizeDigits()
+		}
+		return lexer.punctuation(Minus, "-"), nil
+	case '"':
+		return lexer.tokenizeString('"')
+	// null, true, false
+	case 'n', 't', 'f':
+		return lexer.tokenizeLiterals()
 	}
 
-	if unicode.IsDigit(r) {
-		return lexer.tokenizeDigits(r)
+	if isDigit(b) {
+		return lexer.tokenizeDigits()
 	}
 
-	// null, true, false
-	if r == 'n' || r == 't' || r == 'f' {
-		return lexer.tokenizeLiterals(r)
+	r := rune(b)
+	if b >= utf8.RuneSelf {
+		lexer.fill(utf8.UTFMax - 2)
+		r, _ = utf8.DecodeRune(lexer.buf[lexer.pos-1:])
 	}
-
 	return nil, fmt.Errorf("unrecognised character %c=%d", r, r)
 }
+
+// punctuation takes the value as interface{} so the constant strings are not allocated for every token
+func (lexer *JSONLexer) punctuation(tType int, value interface{}) *Token {
+	return &Token{
+		Type:   tType,
+		Value:  value,
+		Line:   lexer.Line,
+		Column: lexer.Column,
+		Offset: lexer.Offset() - 1,
+	}
+}
@@ -3,10 +3,13 @@ package JSONScanner
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 func TestCompareScannerToNativeLib(t *testing.T) {
@@ -33,7 +36,6 @@ func TestCompareScannerToNativeLib(t *testing.T) {
 			}
 
 			jsonLexer := JSONLexer{
-				Runes:  []rune{},
 				Line:   1,
 				Column: 0,
 			}
@@ -75,3 +77,265 @@ func TestCompareScannerToNativeLib(t *testing.T) {
 	}
 
 }
+
+func TestStreamingLexerMatchesReadJson(t *testing.T) {
+	cases := []string{"../tests/step2/valid2.json",
+		"../tests/step4/valid2.json",
+		"../tests/big/posts.json",
+		"../tests/big/photos.json",
+		"../tests/test/pass1.json"}
+
+	for _, filename := range cases {
+		t.Run(filename, func(t *testing.T) {
+			fileRead, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			jsonLexer := JSONLexer{Line: 1, Column: 0}
+			jsonLexer.ReadJson(fileRead)
+			streamLexer := NewJSONLexer(iotest.OneByteReader(bytes.NewReader(fileRead)))
+
+			for {
+				expected, err := jsonLexer.GetNextToken()
+				if err != nil {
+					t.Fatal(err)
+				}
+				token, err := streamLexer.GetNextToken()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if *token != *expected {
+					t.Fatalf("expected %v, got %v", *expected, *token)
+				}
+				if token.Type == EOF {
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestStreamingLexerReadError(t *testing.T) {
+	readErr := errors.New("connection reset")
+	lexer := NewJSONLexer(io.MultiReader(strings.NewReader(`{"key": `), iotest.ErrReader(readErr)))
+
+	for {
+		token, err := lexer.GetNextToken()
+		if err != nil {
+			if !errors.Is(err, readErr) {
+				t.Errorf("expected %v, got %v", readErr, err)
+			}
+			return
+		}
+		if token.Type == EOF {
+			t.Fatalf("read error reported as EOF")
+		}
+	}
+}
+
+func TestTruncatedStrings(t *testing.T) {
+	for _, input := range []string{`"abc`, `"abc\`, `["\`} {
+		for _, streaming := range []bool{false, true} {
+			var lexer *JSONLexer
+			if streaming {
+				lexer = NewJSONLexer(iotest.OneByteReader(strings.NewReader(input)))
+			} else {
+				lexer = &JSONLexer{Line: 1, Column: 0}
+				lexer.ReadJson([]byte(input))
+			}
+
+			var err error
+			for err == nil {
+				var token *Token
+				if token, err = lexer.GetNextToken(); err == nil && token.Type == EOF {
+					t.Fatalf("%s: truncated string reported as EOF", input)
+				}
+			}
+			if errors.Is(err, io.EOF) || !strings.HasPrefix(err.Error(), "unexpected end of json in string") {
+				t.Errorf("%s: expected an unterminated string got %v", input, err)
+			}
+		}
+	}
+}
+
+var benchmarkFiles = []string{"../tests/big/posts.json",
+	"../tests/big/comments.json",
+	"../tests/big/photos.json",
+	"../tests/big/bitcoin.json"}
+
+func BenchmarkLexer(b *testing.B) {
+	for _, filename := range benchmarkFiles {
+		fileRead, err := os.ReadFile(filename)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(filename, func(b *testing.B) {
+			b.SetBytes(int64(len(fileRead)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				jsonLexer := JSONLexer{Line: 1, Column: 0}
+				jsonLexer.ReadJson(fileRead)
+				for {
+					token, err := jsonLexer.GetNextToken()
+					if err != nil {
+						b.Fatal(err)
+					}
+					if token.Type == EOF {
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkNativeLibDecoderToken(b *testing.B) {
+	for _, filename := range benchmarkFiles {
+		fileRead, err := os.ReadFile(filename)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(filename, func(b *testing.B) {
+			b.SetBytes(int64(len(fileRead)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				jDecoder := json.NewDecoder(bytes.NewReader(fileRead))
+				for {
+					_, err := jDecoder.Token()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTokenPositions(t *testing.T) {
+	jsonLexer := JSONLexer{Line: 1, Column: 0}
+	jsonLexer.ReadJson([]byte("{\n  \"κλειδί\": -12.5e3,\n\t\"b\": [true, null]\n}"))
+
+	expected := []Token{
+		{Type: LeftBracket, Value: "{", Line: 1, Column: 1, Offset: 0},
+		{Type: String, Value: "κλειδί", Line: 2, Column: 3, Offset: 4},
+		{Type: Colon, Value: ":", Line: 2, Column: 11, Offset: 18},
+		{Type: Number, Value: -12.5e3, Line: 2, Column: 13, Offset: 20},
+		{Type: Comma, Value: ",", Line: 2, Column: 20, Offset: 27},
+		{Type: String, Value: "b", Line: 3, Column: 2, Offset: 30},
+		{Type: Colon, Value: ":", Line: 3, Column: 5, Offset: 33},
+		{Type: LeftSquareBracket, Value: "[", Line: 3, Column: 7, Offset: 35},
+		{Type: Literal, Value: true, Line: 3, Column: 8, Offset: 36},
+		{Type: Comma, Value: ",", Line: 3, Column: 12, Offset: 40},
+		{Type: Literal, Value: nil, Line: 3, Column: 14, Offset: 42},
+		{Type: RightSquareBracket, Value: "]", Line: 3, Column: 18, Offset: 46},
+		{Type: RightBracket, Value: "}", Line: 4, Column: 1, Offset: 48},
+		{Type: EOF, Value: "EOF", Line: 4, Column: 1, Offset: 49},
+	}
+
+	for _, want := range expected {
+		token, err := jsonLexer.GetNextToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *token != want {
+			t.Errorf("expected %v, got %v", want, *token)
+		}
+	}
+}
+
+func TestStringDecoding(t *testing.T) {
+	cases := []string{
+		`"plain"`,
+		`"ελληνικά 日本語"`,
+		`"escaped \"quote\" and \\ \/ \b\f\n\r\t"`,
+		`"é䕧ꯍ"`,
+		`"surrogate pair 𝄞"`,
+		`"lone surrogate \ud834 and \udd1e"`,
+		`"two high surrogates \ud834\ud834"`,
+		"\"invalid utf-8 \xff\xfe end\"",
+		"\"truncated sequence \xe6\x97\"",
+	}
+
+	for _, input := range cases {
+		t.Run(input, func(t *testing.T) {
+			jsonLexer := JSONLexer{Line: 1, Column: 0}
+			jsonLexer.ReadJson([]byte(input))
+			token, err := jsonLexer.GetNextToken()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var expected string
+			if err := json.Unmarshal([]byte(input), &expected); err != nil {
+				t.Fatal(err)
+			}
+			if token.Value != expected {
+				t.Errorf("expected %q, got %q", expected, token.Value)
+			}
+		})
+	}
+}
+
+func TestComments(t *testing.T) {
+	input := "// settings\n{\n  \"a\": 1, /* block\n  κλειδί */ \"b\": 2 // trailing\n}/* end */"
+
+	jsonLexer := JSONLexer{Line: 1, Column: 0, AllowComments: true, EmitComments: true}
+	jsonLexer.ReadJson([]byte(input))
+
+	expected := []Token{
+		{Type: Comment, Value: "// settings", Line: 1, Column: 1, Offset: 0},
+		{Type: LeftBracket, Value: "{", Line: 2, Column: 1, Offset: 12},
+		{Type: String, Value: "a", Line: 3, Column: 3, Offset: 16},
+		{Type: Colon, Value: ":", Line: 3, Column: 6, Offset: 19},
+		{Type: Number, Value: float64(1), Line: 3, Column: 8, Offset: 21},
+		{Type: Comma, Value: ",", Line: 3, Column: 9, Offset: 22},
+		{Type: Comment, Value: "/* block\n  κλειδί */", Line: 3, Column: 11, Offset: 24},
+		{Type: String, Value: "b", Line: 4, Column: 13, Offset: 51},
+		{Type: Colon, Value: ":", Line: 4, Column: 16, Offset: 54},
+		{Type: Number, Value: float64(2), Line: 4, Column: 18, Offset: 56},
+		{Type: Comment, Value: "// trailing", Line: 4, Column: 20, Offset: 58},
+		{Type: RightBracket, Value: "}", Line: 5, Column: 1, Offset: 70},
+		{Type: Comment, Value: "/* end */", Line: 5, Column: 2, Offset: 71},
+		{Type: EOF, Value: "EOF", Line: 5, Column: 10, Offset: 80},
+	}
+
+	for _, want := range expected {
+		token, err := jsonLexer.GetNextToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *token != want {
+			t.Errorf("expected %v, got %v", want, *token)
+		}
+	}
+
+	jsonLexer = JSONLexer{Line: 1, Column: 0, AllowComments: true}
+	jsonLexer.ReadJson([]byte(input))
+	for _, want := range expected {
+		if want.Type == Comment {
+			continue
+		}
+		token, err := jsonLexer.GetNextToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *token != want {
+			t.Errorf("expected %v, got %v", want, *token)
+		}
+	}
+
+	for _, invalid := range []string{"/* unterminated", "/ x", "// not allowed"} {
+		jsonLexer = JSONLexer{Line: 1, Column: 0, AllowComments: invalid != "// not allowed"}
+		jsonLexer.ReadJson([]byte(invalid))
+		if _, err := jsonLexer.GetNextToken(); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package JSONSchema
+
+import (
+	"JSONParser/JSONParser"
+)
+
+// InferOptions tunes the schema built by Infer
+type InferOptions struct {
+	// MaxEnum is the largest number of distinct strings inferred as an enum, enums are not inferred when it is 0.
+	// The strings also have to repeat, each distinct value must be seen twice on average.
+	MaxEnum int
+	// RequiredRatio is the fraction of the objects a property must be present in to be required,
+	// 0 means every object
+	RequiredRatio float64
+}
+
+// shape accumulates the values seen at one location of the samples
+type shape struct {
+	nulls, booleans, integers, numbers, strings, arrays, objects int
+
+	// distinct holds the strings seen while there are at most MaxEnum of them
+	distinct     []string
+	seen         map[string]bool
+	tooManyEnums bool
+
+	properties    map[string]*shape
+	propertyNames []string
+	// present counts the objects each property was found in
+	present map[string]int
+
+	items *shape
+}
+
+// Infer builds a draft 2020-12 schema all the samples are valid against.
+// The members of the objects are merged, a property is required when it is present often enough,
+// numbers without fraction are integers, low cardinality strings become enums
+// and locations where null was seen accept it along with the other types.
+// The schema is returned as a parsed document ready for Compile or JSONParser.Stringify.
+func Infer(samples []interface{}, options InferOptions) *JSONParser.OrderedObject {
+	root := &shape{}
+	for _, sample := range samples {
+		root.add(sample, options)
+	}
+
+	schema := root.schema(options)
+	document := JSONParser.NewOrderedObject()
+	document.Set("$schema", "https://json-schema.org/draft/2020-12/schema")
+	for _, k := range schema.Keys() {
+		v, _ := schema.Get(k)
+		document.Set(k, v)
+	}
+	return document
+}
+
+func (s *shape) add(value interface{}, options InferOptions) {
+	switch v := value.(type) {
+	case nil:
+		s.nulls++
+	case bool:
+		s.booleans++
+	case string:
+		s.strings++
+		s.addString(v, options)
+	case []interface{}:
+		s.arrays++
+		if s.items == nil {
+			s.items = &shape{}
+		}
+		for _, element := range v {
+			s.items.add(element, options)
+		}
+	case map[string]interface{}, *JSONParser.OrderedObject:
+		s.objects++
+		if s.properties == nil {
+			s.properties = make(map[string]*shape)
+			s.present = make(map[string]int)
+		}
+		keys, _ := JSONParser.ObjectKeys(v)
+		for _, k := range keys {
+			property, ok := s.properties[k]
+			if !ok {
+				property = &shape{}
+				s.properties[k] = property
+				s.propertyNames = append(s.propertyNames, k)
+			}
+			s.present[k]++
+			element, _ := JSONParser.Member(v, k)
+			property.add(element, options)
+		}
+	default:
+		if n, ok := JSONParser.Number(v); ok && n.IsInt() {
+			s.integers++
+		} else {
+			s.numbers++
+		}
+	}
+}
+
+func (s *shape) addString(str string, options InferOptions) {
+	if s.tooManyEnums || s.seen[str] {
+		return
+	}
+	if len(s.distinct) == options.MaxEnum {
+		s.tooManyEnums = true
+		s.distinct, s.seen = nil, nil
+		return
+	}
+	if s.seen == nil {
+		s.seen = make(map[string]bool)
+	}
+	s.seen[str] = true
+	s.distinct = append(s.distinct, str)
+}
+
+func (s *shape) schema(options InferOptions) *JSONParser.OrderedObject {
+	schema := JSONParser.NewOrderedObject()
+
+	var types []interface{}
+	if s.objects > 0 {
+		types = append(types, "object")
+	}
+	if s.arrays > 0 {
+		types = append(types, "array")
+	}
+	if s.strings > 0 {
+		types = append(types, "string")
+	}
+	if s.numbers > 0 {
+		types = append(types, "number")
+	} else if s.integers > 0 {
+		types = append(types, "integer")
+	}
+	if s.booleans > 0 {
+		types = append(types, "boolean")
+	}
+	if s.nulls > 0 {
+		types = append(types, "null")
+	}
+	switch len(types) {
+	case 0:
+		// nothing was seen here, like the items of empty arrays, any value is accepted
+		return schema
+	case 1:
+		schema.Set("type", types[0])
+	default:
+		schema.Set("type", types)
+	}
+
+	if s.isEnum() {
+		enum := make([]interface{}, 0, len(s.distinct)+1)
+		for _, str := range s.distinct {
+			enum = append(enum, str)
+		}
+		if s.nulls > 0 {
+			enum = append(enum, nil)
+		}
+		schema.Set("enum", enum)
+	}
+
+	if s.objects > 0 {
+		properties := JSONParser.NewOrderedObject()
+		required := []interface{}{}
+		ratio := options.RequiredRatio
+		if ratio <= 0 {
+			ratio = 1
+		}
+		for _, name := range s.propertyNames {
+			properties.Set(name, s.properties[name].schema(options))
+			if float64(s.present[name]) >= ratio*float64(s.objects) {
+				required = append(required, name)
+			}
+		}
+		schema.Set("properties", properties)
+		if len(required) > 0 {
+			schema.Set("required", required)
+		}
+	}
+
+	if s.arrays > 0 {
+		if items := s.items.schema(options); items.Len() > 0 {
+			schema.Set("items", items)
+		}
+	}
+	return schema
+}
+
+// isEnum tells if the strings are the only values besides null and few enough to be listed
+func (s *shape) isEnum() bool {
+	others := s.booleans + s.integers + s.numbers + s.arrays + s.objects
+	return s.strings > 0 && others == 0 && !s.tooManyEnums && s.strings >= 2*len(s.distinct)
+}
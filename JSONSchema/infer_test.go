@@ -0,0 +1,103 @@
+package JSONSchema
+
+import (
+	"JSONParser/JSONParser"
+	"os"
+	"testing"
+)
+
+func stringify(t *testing.T, value interface{}) string {
+	t.Helper()
+	output, err := JSONParser.Stringify(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(output)
+}
+
+func TestInfer(t *testing.T) {
+	samples := []interface{}{
+		parse(t, `{"id": 1, "status": "open", "score": 1, "owner": {"name": "ann"}, "tags": ["a"], "note": null}`),
+		parse(t, `{"id": 2, "status": "closed", "score": 2.5, "owner": null, "tags": [], "note": "late"}`),
+		parse(t, `{"id": 3, "status": "open", "score": 3, "owner": {"name": "bob", "admin": true}, "tags": ["b", 1]}`),
+		parse(t, `{"id": 4, "status": null, "score": 4, "owner": {"name": "cid"}, "tags": []}`),
+		parse(t, `{"id": 5, "status": "closed", "score": 5, "owner": {"name": "dan"}, "tags": ["c"]}`),
+	}
+
+	schema := Infer(samples, InferOptions{MaxEnum: 5})
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+		`"id":{"type":"integer"},` +
+		`"status":{"type":["string","null"],"enum":["open","closed",null]},` +
+		`"score":{"type":"number"},` +
+		`"owner":{"type":["object","null"],"properties":{"name":{"type":"string"},"admin":{"type":"boolean"}},"required":["name"]},` +
+		`"tags":{"type":"array","items":{"type":["string","integer"]}},` +
+		`"note":{"type":["string","null"]}` +
+		`},"required":["id","status","score","owner","tags"]}`
+	if actual := stringify(t, schema); actual != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, actual)
+	}
+
+	compiled, err := Compile(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sample := range samples {
+		if err := compiled.Validate(sample); err != nil {
+			t.Errorf("%s: unexpected error %v", stringify(t, sample), err)
+		}
+	}
+}
+
+func TestInferOptions(t *testing.T) {
+	samples := []interface{}{
+		parse(t, `{"a": "x", "b": 1}`),
+		parse(t, `{"a": "y", "b": 2}`),
+		parse(t, `{"a": "x"}`),
+		parse(t, `{"a": "y", "c": []}`),
+	}
+
+	tests := []struct {
+		options  InferOptions
+		expected string
+	}{
+		{InferOptions{}, `{"type":"object","properties":{"a":{"type":"string"},"b":{"type":"integer"},"c":{"type":"array"}},"required":["a"]}`},
+		{InferOptions{MaxEnum: 2, RequiredRatio: 0.5}, `{"type":"object","properties":{"a":{"type":"string","enum":["x","y"]},"b":{"type":"integer"},"c":{"type":"array"}},"required":["a","b"]}`},
+		{InferOptions{MaxEnum: 1}, `{"type":"object","properties":{"a":{"type":"string"},"b":{"type":"integer"},"c":{"type":"array"}},"required":["a"]}`},
+	}
+
+	for _, test := range tests {
+		schema := Infer(samples, test.options)
+		schema.Delete("$schema")
+		if actual := stringify(t, schema); actual != test.expected {
+			t.Errorf("%+v: expected\n%s\ngot\n%s", test.options, test.expected, actual)
+		}
+	}
+
+	if actual := stringify(t, Infer(nil, InferOptions{})); actual != `{"$schema":"https://json-schema.org/draft/2020-12/schema"}` {
+		t.Errorf("unexpected schema without samples %s", actual)
+	}
+}
+
+func TestInferPosts(t *testing.T) {
+	input, err := os.ReadFile("../tests/big/posts.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	posts := parse(t, string(input))
+
+	schema := Infer(posts.([]interface{}), InferOptions{MaxEnum: 10})
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+		`"userId":{"type":"integer"},"id":{"type":"integer"},"title":{"type":"string"},"body":{"type":"string"}` +
+		`},"required":["userId","id","title","body"]}`
+	if actual := stringify(t, schema); actual != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, actual)
+	}
+
+	compiled, err := Compile(Infer([]interface{}{posts}, InferOptions{MaxEnum: 10}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := compiled.Validate(posts); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,420 @@
+package JSONSchema
+
+import (
+	"JSONParser/JSONParser"
+	"JSONParser/JSONPointer"
+	"fmt"
+	"math"
+	"math/big"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Schema is a compiled draft 2020-12 JSON Schema.
+// The supported keywords are type, enum, const, properties, additionalProperties, required,
+// prefixItems, items, minItems, maxItems, uniqueItems, minimum, maximum, exclusiveMinimum,
+// exclusiveMaximum, multipleOf, minLength, maxLength, pattern, $ref, $defs, $anchor,
+// allOf, anyOf, oneOf and not, the other keywords are ignored.
+// A Schema is safe for concurrent use.
+type Schema struct {
+	root *schema
+}
+
+type schema struct {
+	// location is the pointer to the schema in the root document
+	location JSONPointer.Pointer
+	// always is set for the true and false schemas
+	always *bool
+
+	types    []string
+	enum     []interface{}
+	hasEnum  bool
+	constant interface{}
+	hasConst bool
+
+	properties           map[string]*schema
+	propertyNames        []string
+	additionalProperties *schema
+	required             []string
+
+	prefixItems []*schema
+	items       *schema
+	minItems    *int
+	maxItems    *int
+	uniqueItems bool
+
+	minimum          *big.Rat
+	maximum          *big.Rat
+	exclusiveMinimum *big.Rat
+	exclusiveMaximum *big.Rat
+	multipleOf       *big.Rat
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+
+	ref   *schema
+	allOf []*schema
+	anyOf []*schema
+	oneOf []*schema
+	not   *schema
+}
+
+var typeNames = map[string]bool{
+	"null": true, "boolean": true, "object": true, "array": true,
+	"number": true, "integer": true, "string": true,
+}
+
+type compiler struct {
+	document interface{}
+	id       string
+	compiled map[string]*schema
+	anchors  map[string]JSONPointer.Pointer
+}
+
+// Compile checks a schema parsed by JSONParser.Parse and prepares it for validation.
+// Only the references inside the document are resolved: #, #/json/pointer and #anchor.
+func Compile(document interface{}) (*Schema, error) {
+	c := &compiler{
+		document: document,
+		compiled: make(map[string]*schema),
+		anchors:  make(map[string]JSONPointer.Pointer),
+	}
+	if id, ok := JSONParser.Member(document, "$id"); ok {
+		c.id, _ = id.(string)
+	}
+	c.findAnchors(document, JSONPointer.Pointer{})
+
+	root, err := c.compile(JSONPointer.Pointer{})
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkCycles(); err != nil {
+		return nil, err
+	}
+	return &Schema{root: root}, nil
+}
+
+// checkCycles rejects a schema applied again to the same instance through $ref, allOf, anyOf, oneOf and not,
+// like {"$ref": "#"}, validating it would never end
+func (c *compiler) checkCycles() error {
+	const (
+		visiting = iota + 1
+		done
+	)
+	state := make(map[*schema]int)
+
+	var visit func(s *schema) error
+	visit = func(s *schema) error {
+		switch state[s] {
+		case visiting:
+			return c.errorf(s.location, "the schema applies itself to the same value through a reference cycle")
+		case done:
+			return nil
+		}
+		state[s] = visiting
+		for _, next := range s.inPlace() {
+			if err := visit(next); err != nil {
+				return err
+			}
+		}
+		state[s] = done
+		return nil
+	}
+
+	locations := make([]string, 0, len(c.compiled))
+	for location := range c.compiled {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+	for _, location := range locations {
+		if err := visit(c.compiled[location]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inPlace returns the subschemas applied to the same instance as s
+func (s *schema) inPlace() []*schema {
+	var subschemas []*schema
+	if s.ref != nil {
+		subschemas = append(subschemas, s.ref)
+	}
+	subschemas = append(subschemas, s.allOf...)
+	subschemas = append(subschemas, s.anyOf...)
+	subschemas = append(subschemas, s.oneOf...)
+	if s.not != nil {
+		subschemas = append(subschemas, s.not)
+	}
+	return subschemas
+}
+
+// findAnchors records the location of every $anchor
+func (c *compiler) findAnchors(value interface{}, location JSONPointer.Pointer) {
+	if anchor, ok := JSONParser.Member(value, "$anchor"); ok {
+		if name, ok := anchor.(string); ok {
+			c.anchors[name] = location
+		}
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		for i, element := range v {
+			c.findAnchors(element, location.Append(strconv.Itoa(i)))
+		}
+	case map[string]interface{}, *JSONParser.OrderedObject:
+		keys, _ := JSONParser.ObjectKeys(v)
+		for _, k := range keys {
+			// the values of these keywords are data not schemas
+			if k == "enum" || k == "const" {
+				continue
+			}
+			element, _ := JSONParser.Member(v, k)
+			c.findAnchors(element, location.Append(k))
+		}
+	}
+}
+
+func (c *compiler) errorf(location JSONPointer.Pointer, format string, args ...interface{}) error {
+	return fmt.Errorf("json schema %s: %s", displayPath(location), fmt.Sprintf(format, args...))
+}
+
+// compile returns the schema at location, each location is compiled once so references can be recursive
+func (c *compiler) compile(location JSONPointer.Pointer) (*schema, error) {
+	key := location.String()
+	if s, ok := c.compiled[key]; ok {
+		return s, nil
+	}
+
+	value, err := location.Get(c.document)
+	if err != nil {
+		return nil, err
+	}
+	s := &schema{location: location}
+	c.compiled[key] = s
+
+	if b, ok := value.(bool); ok {
+		s.always = &b
+		return s, nil
+	}
+	if _, ok := JSONParser.ObjectKeys(value); !ok {
+		return nil, c.errorf(location, "a schema must be an object or a boolean")
+	}
+
+	if err := c.compileKeywords(s, value); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (c *compiler) compileKeywords(s *schema, value interface{}) error {
+	location := s.location
+	var err error
+
+	if t, ok := JSONParser.Member(value, "type"); ok {
+		names, isArray := t.([]interface{})
+		if !isArray {
+			names = []interface{}{t}
+		}
+		for _, n := range names {
+			name, ok := n.(string)
+			if !ok || !typeNames[name] {
+				return c.errorf(location.Append("type"), "invalid type %v", n)
+			}
+			s.types = append(s.types, name)
+		}
+	}
+
+	if enum, ok := JSONParser.Member(value, "enum"); ok {
+		values, isArray := enum.([]interface{})
+		if !isArray {
+			return c.errorf(location.Append("enum"), "must be an array")
+		}
+		s.enum, s.hasEnum = values, true
+	}
+	s.constant, s.hasConst = JSONParser.Member(value, "const")
+
+	if properties, ok := JSONParser.Member(value, "properties"); ok {
+		names, isObject := JSONParser.ObjectKeys(properties)
+		if !isObject {
+			return c.errorf(location.Append("properties"), "must be an object")
+		}
+		s.properties = make(map[string]*schema, len(names))
+		s.propertyNames = names
+		for _, name := range names {
+			if s.properties[name], err = c.compile(location.Append("properties").Append(name)); err != nil {
+				return err
+			}
+		}
+	}
+	if s.additionalProperties, err = c.subschema(value, location, "additionalProperties"); err != nil {
+		return err
+	}
+	if required, ok := JSONParser.Member(value, "required"); ok {
+		names, isArray := required.([]interface{})
+		if !isArray {
+			return c.errorf(location.Append("required"), "must be an array of strings")
+		}
+		for _, n := range names {
+			name, ok := n.(string)
+			if !ok {
+				return c.errorf(location.Append("required"), "must be an array of strings")
+			}
+			s.required = append(s.required, name)
+		}
+	}
+
+	if s.prefixItems, err = c.subschemas(value, location, "prefixItems"); err != nil {
+		return err
+	}
+	if s.items, err = c.subschema(value, location, "items"); err != nil {
+		return err
+	}
+	if s.minItems, err = c.count(value, location, "minItems"); err != nil {
+		return err
+	}
+	if s.maxItems, err = c.count(value, location, "maxItems"); err != nil {
+		return err
+	}
+	if unique, ok := JSONParser.Member(value, "uniqueItems"); ok {
+		if s.uniqueItems, ok = unique.(bool); !ok {
+			return c.errorf(location.Append("uniqueItems"), "must be a boolean")
+		}
+	}
+
+	bounds := []struct {
+		keyword string
+		value   **big.Rat
+	}{
+		{"minimum", &s.minimum},
+		{"maximum", &s.maximum},
+		{"exclusiveMinimum", &s.exclusiveMinimum},
+		{"exclusiveMaximum", &s.exclusiveMaximum},
+		{"multipleOf", &s.multipleOf},
+	}
+	for _, bound := range bounds {
+		if n, ok := JSONParser.Member(value, bound.keyword); ok {
+			if *bound.value, ok = JSONParser.Number(n); !ok {
+				return c.errorf(location.Append(bound.keyword), "must be a number")
+			}
+		}
+	}
+	if s.multipleOf != nil && s.multipleOf.Sign() <= 0 {
+		return c.errorf(location.Append("multipleOf"), "must be greater than 0")
+	}
+
+	if s.minLength, err = c.count(value, location, "minLength"); err != nil {
+		return err
+	}
+	if s.maxLength, err = c.count(value, location, "maxLength"); err != nil {
+		return err
+	}
+	if pattern, ok := JSONParser.Member(value, "pattern"); ok {
+		text, isString := pattern.(string)
+		if !isString {
+			return c.errorf(location.Append("pattern"), "must be a string")
+		}
+		if s.pattern, err = regexp.Compile(text); err != nil {
+			return c.errorf(location.Append("pattern"), "%v", err)
+		}
+	}
+
+	if ref, ok := JSONParser.Member(value, "$ref"); ok {
+		text, isString := ref.(string)
+		if !isString {
+			return c.errorf(location.Append("$ref"), "must be a string")
+		}
+		if s.ref, err = c.resolve(text, location.Append("$ref")); err != nil {
+			return err
+		}
+	}
+	if s.allOf, err = c.subschemas(value, location, "allOf"); err != nil {
+		return err
+	}
+	if s.anyOf, err = c.subschemas(value, location, "anyOf"); err != nil {
+		return err
+	}
+	if s.oneOf, err = c.subschemas(value, location, "oneOf"); err != nil {
+		return err
+	}
+	if s.not, err = c.subschema(value, location, "not"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// resolve compiles the schema a $ref points to
+func (c *compiler) resolve(ref string, location JSONPointer.Pointer) (*schema, error) {
+	if c.id != "" && strings.HasPrefix(ref, c.id) {
+		ref = ref[len(c.id):]
+	}
+	if !strings.HasPrefix(ref, "#") {
+		return nil, c.errorf(location, "can't resolve %q, only references inside the schema are supported", ref)
+	}
+
+	fragment, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return nil, c.errorf(location, "invalid reference %q", ref)
+	}
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		target, ok := c.anchors[fragment]
+		if !ok {
+			return nil, c.errorf(location, "anchor %q not found", fragment)
+		}
+		return c.compile(target)
+	}
+
+	target, err := JSONPointer.Parse(fragment)
+	if err != nil {
+		return nil, c.errorf(location, "%v", err)
+	}
+	if _, err := target.Get(c.document); err != nil {
+		return nil, c.errorf(location, "can't resolve %q: %v", ref, err)
+	}
+	return c.compile(target)
+}
+
+func (c *compiler) subschema(value interface{}, location JSONPointer.Pointer, keyword string) (*schema, error) {
+	if _, ok := JSONParser.Member(value, keyword); !ok {
+		return nil, nil
+	}
+	return c.compile(location.Append(keyword))
+}
+
+func (c *compiler) subschemas(value interface{}, location JSONPointer.Pointer, keyword string) ([]*schema, error) {
+	v, ok := JSONParser.Member(value, keyword)
+	if !ok {
+		return nil, nil
+	}
+	array, isArray := v.([]interface{})
+	if !isArray || len(array) == 0 {
+		return nil, c.errorf(location.Append(keyword), "must be a non empty array of schemas")
+	}
+
+	schemas := make([]*schema, len(array))
+	for i := range array {
+		var err error
+		if schemas[i], err = c.compile(location.Append(keyword).Append(strconv.Itoa(i))); err != nil {
+			return nil, err
+		}
+	}
+	return schemas, nil
+}
+
+// count reads the non negative integer of keywords like minLength
+func (c *compiler) count(value interface{}, location JSONPointer.Pointer, keyword string) (*int, error) {
+	v, ok := JSONParser.Member(value, keyword)
+	if !ok {
+		return nil, nil
+	}
+	n, ok := JSONParser.Number(v)
+	if !ok || !n.IsInt() || n.Sign() < 0 || !n.Num().IsInt64() || n.Num().Int64() > math.MaxInt32 {
+		return nil, c.errorf(location.Append(keyword), "must be a non negative integer")
+	}
+	i := int(n.Num().Int64())
+	return &i, nil
+}
@@ -0,0 +1,104 @@
+package JSONSchema
+
+import (
+	"JSONParser/JSONParser"
+	"strings"
+	"testing"
+)
+
+func parse(t *testing.T, input string) interface{} {
+	t.Helper()
+	value, err := JSONParser.ParseWithOptions([]byte(input), JSONParser.Options{OrderedObjects: true})
+	if err != nil {
+		t.Fatalf("%s: %v", input, err)
+	}
+	return value
+}
+
+func compile(t *testing.T, input string) *Schema {
+	t.Helper()
+	schema, err := Compile(parse(t, input))
+	if err != nil {
+		t.Fatalf("%s: %v", input, err)
+	}
+	return schema
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		schema, message string
+	}{
+		{`1`, "json schema (root): a schema must be an object or a boolean"},
+		{`{"type": "int"}`, "json schema /type: invalid type int"},
+		{`{"type": ["string", 1]}`, "json schema /type: invalid type 1"},
+		{`{"properties": {"a": []}}`, "json schema /properties/a: a schema must be an object or a boolean"},
+		{`{"required": ["a", 1]}`, "json schema /required: must be an array of strings"},
+		{`{"minLength": -1}`, "json schema /minLength: must be a non negative integer"},
+		{`{"maxItems": 1.5}`, "json schema /maxItems: must be a non negative integer"},
+		{`{"minimum": "1"}`, "json schema /minimum: must be a number"},
+		{`{"multipleOf": 0}`, "json schema /multipleOf: must be greater than 0"},
+		{`{"pattern": "("}`, "json schema /pattern: error parsing regexp"},
+		{`{"allOf": []}`, "json schema /allOf: must be a non empty array of schemas"},
+		{`{"enum": 1}`, "json schema /enum: must be an array"},
+		{`{"$ref": "#/$defs/missing"}`, `json schema /$ref: can't resolve "#/$defs/missing"`},
+		{`{"$ref": "#missing"}`, `json schema /$ref: anchor "missing" not found`},
+		{`{"$ref": "https://example.com/schema"}`, "only references inside the schema are supported"},
+		{`{"not": {"$ref": "#/$defs/a"}, "$defs": {"a": {"type": 1}}}`, "json schema /$defs/a/type: invalid type 1"},
+		{`{"$ref": "#"}`, "json schema (root): the schema applies itself to the same value through a reference cycle"},
+		{`{"$ref": "#/$defs/a", "$defs": {"a": {"$ref": "#/$defs/b"}, "b": {"anyOf": [{"type": "null"}, {"$ref": "#/$defs/a"}]}}}`, "reference cycle"},
+		{`{"properties": {"p": {"allOf": [{"not": {"$ref": "#/properties/p"}}]}}}`, "reference cycle"},
+	}
+
+	for _, test := range tests {
+		_, err := Compile(parse(t, test.schema))
+		if err == nil || !strings.Contains(err.Error(), test.message) {
+			t.Errorf("%s: expected %q got %v", test.schema, test.message, err)
+		}
+	}
+}
+
+func TestReferences(t *testing.T) {
+	schema := compile(t, `{
+		"$id": "https://example.com/tree",
+		"$ref": "#/$defs/node",
+		"$defs": {
+			"node": {
+				"type": "object",
+				"properties": {
+					"value": {"$ref": "#positive"},
+					"children": {"type": "array", "items": {"$ref": "https://example.com/tree#/$defs/node"}}
+				},
+				"required": ["value"]
+			},
+			"positive": {"$anchor": "positive", "type": "integer", "exclusiveMinimum": 0},
+			"a~b/c": {"type": "null"}
+		},
+		"properties": {"tag": {"$ref": "#/$defs/a~0b~1c"}}
+	}`)
+
+	valid := parse(t, `{"value": 1, "children": [{"value": 2, "children": []}, {"value": 3}], "tag": null}`)
+	if err := schema.Validate(valid); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	invalid := parse(t, `{"value": 1, "children": [{"value": 2, "children": [{"value": 0}, {}]}], "tag": 1}`)
+	expected := []string{
+		"/tag: expected null got integer (schema /$defs/a~0b~1c/type)",
+		"/children/0/children/0/value: 0 is not greater than 0 (schema /$defs/positive/exclusiveMinimum)",
+		"/children/0/children/1: missing required property \"value\" (schema /$defs/node/required)",
+	}
+	assertErrors(t, schema.Validate(invalid), expected)
+}
+
+func TestBooleanSchemas(t *testing.T) {
+	schema := compile(t, `{"properties": {"any": true, "none": false}, "items": false}`)
+	if err := schema.Validate(parse(t, `{"any": [1, {}]}`)); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	assertErrors(t, schema.Validate(parse(t, `{"none": 1}`)), []string{"/none: no value is allowed (schema /properties/none)"})
+	assertErrors(t, schema.Validate(parse(t, `[1]`)), []string{"/0: no value is allowed (schema /items)"})
+
+	if err := compile(t, `false`).Validate(nil); err == nil {
+		t.Errorf("expected the false schema to reject null")
+	}
+}
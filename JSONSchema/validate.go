@@ -0,0 +1,266 @@
+package JSONSchema
+
+import (
+	"JSONParser/JSONParser"
+	"JSONParser/JSONPointer"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationError is a keyword the instance doesn't satisfy
+type ValidationError struct {
+	// InstancePath is the location of the invalid value in the instance
+	InstancePath JSONPointer.Pointer
+	// SchemaPath is the location of the failed keyword in the schema
+	SchemaPath JSONPointer.Pointer
+	Msg        string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s (schema %s)", displayPath(e.InstancePath), e.Msg, displayPath(e.SchemaPath))
+}
+
+func displayPath(p JSONPointer.Pointer) string {
+	if len(p) == 0 {
+		return "(root)"
+	}
+	return p.String()
+}
+
+// ValidationErrors holds all the errors found in an instance
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Validate checks an instance parsed by JSONParser.Parse,
+// it returns nil when the instance is valid and ValidationErrors otherwise.
+func (s *Schema) Validate(instance interface{}) error {
+	var errs ValidationErrors
+	s.root.validate(instance, JSONPointer.Pointer{}, &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func (s *schema) valid(instance interface{}, path JSONPointer.Pointer) bool {
+	var errs ValidationErrors
+	s.validate(instance, path, &errs)
+	return len(errs) == 0
+}
+
+// instanceType returns the json type of a value, integer for a number without fraction
+func instanceType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}, *JSONParser.OrderedObject:
+		return "object"
+	}
+	if n, ok := JSONParser.Number(value); ok {
+		if n.IsInt() {
+			return "integer"
+		}
+		return "number"
+	}
+	return "unknown"
+}
+
+func (s *schema) validate(instance interface{}, path JSONPointer.Pointer, errs *ValidationErrors) {
+	fail := func(keyword string, format string, args ...interface{}) {
+		schemaPath := s.location
+		if keyword != "" {
+			schemaPath = schemaPath.Append(keyword)
+		}
+		*errs = append(*errs, &ValidationError{InstancePath: path, SchemaPath: schemaPath, Msg: fmt.Sprintf(format, args...)})
+	}
+
+	if s.always != nil {
+		if !*s.always {
+			fail("", "no value is allowed")
+		}
+		return
+	}
+
+	actualType := instanceType(instance)
+	if len(s.types) > 0 {
+		matched := false
+		for _, t := range s.types {
+			if t == actualType || (t == "number" && actualType == "integer") {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			fail("type", "expected %s got %s", strings.Join(s.types, " or "), actualType)
+		}
+	}
+
+	if s.hasEnum {
+		found := false
+		for _, e := range s.enum {
+			if JSONParser.Equal(e, instance) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("enum", "value is not one of the enum values")
+		}
+	}
+	if s.hasConst && !JSONParser.Equal(s.constant, instance) {
+		fail("const", "value is not the const value")
+	}
+
+	switch actualType {
+	case "object":
+		s.validateObject(instance, path, errs, fail)
+	case "array":
+		s.validateArray(instance.([]interface{}), path, errs, fail)
+	case "number", "integer":
+		n, _ := JSONParser.Number(instance)
+		s.validateNumber(n, fail)
+	case "string":
+		s.validateString(instance.(string), fail)
+	}
+
+	if s.ref != nil {
+		s.ref.validate(instance, path, errs)
+	}
+	for _, sub := range s.allOf {
+		sub.validate(instance, path, errs)
+	}
+	if len(s.anyOf) > 0 {
+		matched := false
+		for _, sub := range s.anyOf {
+			if sub.valid(instance, path) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			fail("anyOf", "value doesn't match any schema")
+		}
+	}
+	if len(s.oneOf) > 0 {
+		matched := 0
+		for _, sub := range s.oneOf {
+			if sub.valid(instance, path) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			fail("oneOf", "value matches %d schemas instead of exactly one", matched)
+		}
+	}
+	if s.not != nil && s.not.valid(instance, path) {
+		fail("not", "value matches the schema it must not match")
+	}
+}
+
+func (s *schema) validateObject(object interface{}, path JSONPointer.Pointer, errs *ValidationErrors, fail func(string, string, ...interface{})) {
+	for _, name := range s.propertyNames {
+		if value, ok := JSONParser.Member(object, name); ok {
+			s.properties[name].validate(value, path.Append(name), errs)
+		}
+	}
+
+	keys, _ := JSONParser.ObjectKeys(object)
+	if s.additionalProperties != nil {
+		for _, k := range keys {
+			if _, declared := s.properties[k]; !declared {
+				value, _ := JSONParser.Member(object, k)
+				s.additionalProperties.validate(value, path.Append(k), errs)
+			}
+		}
+	}
+
+	for _, name := range s.required {
+		if _, ok := JSONParser.Member(object, name); !ok {
+			fail("required", "missing required property %q", name)
+		}
+	}
+}
+
+func (s *schema) validateArray(array []interface{}, path JSONPointer.Pointer, errs *ValidationErrors, fail func(string, string, ...interface{})) {
+	for i, element := range array {
+		elementPath := path.Append(strconv.Itoa(i))
+		if i < len(s.prefixItems) {
+			s.prefixItems[i].validate(element, elementPath, errs)
+		} else if s.items != nil {
+			s.items.validate(element, elementPath, errs)
+		}
+	}
+
+	if s.minItems != nil && len(array) < *s.minItems {
+		fail("minItems", "expected at least %d items got %d", *s.minItems, len(array))
+	}
+	if s.maxItems != nil && len(array) > *s.maxItems {
+		fail("maxItems", "expected at most %d items got %d", *s.maxItems, len(array))
+	}
+	if s.uniqueItems {
+		for i := range array {
+			for j := i + 1; j < len(array); j++ {
+				if JSONParser.Equal(array[i], array[j]) {
+					fail("uniqueItems", "items %d and %d are equal", i, j)
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *schema) validateNumber(n *big.Rat, fail func(string, string, ...interface{})) {
+	if s.minimum != nil && n.Cmp(s.minimum) < 0 {
+		fail("minimum", "%s is less than the minimum %s", formatNumber(n), formatNumber(s.minimum))
+	}
+	if s.maximum != nil && n.Cmp(s.maximum) > 0 {
+		fail("maximum", "%s is greater than the maximum %s", formatNumber(n), formatNumber(s.maximum))
+	}
+	if s.exclusiveMinimum != nil && n.Cmp(s.exclusiveMinimum) <= 0 {
+		fail("exclusiveMinimum", "%s is not greater than %s", formatNumber(n), formatNumber(s.exclusiveMinimum))
+	}
+	if s.exclusiveMaximum != nil && n.Cmp(s.exclusiveMaximum) >= 0 {
+		fail("exclusiveMaximum", "%s is not less than %s", formatNumber(n), formatNumber(s.exclusiveMaximum))
+	}
+	if s.multipleOf != nil && !new(big.Rat).Quo(n, s.multipleOf).IsInt() {
+		fail("multipleOf", "%s is not a multiple of %s", formatNumber(n), formatNumber(s.multipleOf))
+	}
+}
+
+func (s *schema) validateString(str string, fail func(string, string, ...interface{})) {
+	length := utf8.RuneCountInString(str)
+	if s.minLength != nil && length < *s.minLength {
+		fail("minLength", "expected at least %d characters got %d", *s.minLength, length)
+	}
+	if s.maxLength != nil && length > *s.maxLength {
+		fail("maxLength", "expected at most %d characters got %d", *s.maxLength, length)
+	}
+	if s.pattern != nil && !s.pattern.MatchString(str) {
+		fail("pattern", "value doesn't match the pattern %s", s.pattern)
+	}
+}
+
+// formatNumber writes a number of an error message in the usual decimal form
+func formatNumber(n *big.Rat) string {
+	if n.IsInt() {
+		return n.Num().String()
+	}
+	f, _ := n.Float64()
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,173 @@
+package JSONSchema
+
+import (
+	"JSONParser/JSONParser"
+	"JSONParser/JSONScanner"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func assertErrors(t *testing.T, err error, expected []string) {
+	t.Helper()
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors got %v", err)
+	}
+	var messages []string
+	for _, e := range errs {
+		messages = append(messages, e.Error())
+	}
+	if !reflect.DeepEqual(expected, messages) {
+		t.Errorf("expected\n%q\ngot\n%q", expected, messages)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	schema := compile(t, `{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "minLength": 2, "maxLength": 5, "pattern": "^[a-z]+$"},
+			"age": {"type": "integer", "minimum": 0, "maximum": 150},
+			"score": {"type": "number", "exclusiveMaximum": 1, "multipleOf": 0.01},
+			"role": {"enum": ["admin", "user", null]},
+			"version": {"const": 2},
+			"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1, "maxItems": 3, "uniqueItems": true},
+			"point": {"type": "array", "prefixItems": [{"type": "number"}, {"type": "number"}], "items": false}
+		},
+		"additionalProperties": {"type": ["boolean", "null"]},
+		"required": ["name", "age"]
+	}`)
+
+	valid := []string{
+		`{"name": "ann", "age": 30}`,
+		`{"name": "bob", "age": 30.0, "score": 0.07, "role": null, "version": 2.0, "tags": ["a", "b"], "point": [1, 2.5], "extra": true}`,
+		`{"name": "cid", "age": 0, "score": -3, "point": [1]}`,
+	}
+	for _, input := range valid {
+		if err := schema.Validate(parse(t, input)); err != nil {
+			t.Errorf("%s: unexpected error %v", input, err)
+		}
+	}
+
+	invalid := parse(t, `{
+		"name": "Alexander",
+		"age": 30.5,
+		"score": 1,
+		"role": "root",
+		"version": "2",
+		"tags": ["a", 1, "a", "b"],
+		"point": [1, 2, 3],
+		"extra": "yes"
+	}`)
+	assertErrors(t, schema.Validate(invalid), []string{
+		"/name: expected at most 5 characters got 9 (schema /properties/name/maxLength)",
+		"/name: value doesn't match the pattern ^[a-z]+$ (schema /properties/name/pattern)",
+		"/age: expected integer got number (schema /properties/age/type)",
+		"/score: 1 is not less than 1 (schema /properties/score/exclusiveMaximum)",
+		"/role: value is not one of the enum values (schema /properties/role/enum)",
+		"/version: value is not the const value (schema /properties/version/const)",
+		"/tags/1: expected string got integer (schema /properties/tags/items/type)",
+		"/tags: expected at most 3 items got 4 (schema /properties/tags/maxItems)",
+		"/tags: items 0 and 2 are equal (schema /properties/tags/uniqueItems)",
+		"/point/2: no value is allowed (schema /properties/point/items)",
+		"/extra: expected boolean or null got string (schema /additionalProperties/type)",
+	})
+
+	assertErrors(t, schema.Validate(parse(t, `[]`)), []string{"(root): expected object got array (schema /type)"})
+	assertErrors(t, schema.Validate(parse(t, `{"name": "x", "score": 0.015}`)), []string{
+		"/name: expected at least 2 characters got 1 (schema /properties/name/minLength)",
+		"/score: 0.015 is not a multiple of 0.01 (schema /properties/score/multipleOf)",
+		"(root): missing required property \"age\" (schema /required)",
+	})
+}
+
+func TestCombinators(t *testing.T) {
+	schema := compile(t, `{
+		"allOf": [{"type": "number"}, {"minimum": 10}],
+		"anyOf": [{"multipleOf": 3}, {"multipleOf": 5}],
+		"oneOf": [{"maximum": 20}, {"minimum": 15}],
+		"not": {"const": 30}
+	}`)
+
+	tests := []struct {
+		instance string
+		expected []string
+	}{
+		{`12`, nil},
+		{`25`, nil},
+		{`9`, []string{"(root): 9 is less than the minimum 10 (schema /allOf/1/minimum)"}},
+		{`11`, []string{"(root): value doesn't match any schema (schema /anyOf)"}},
+		{`15`, []string{"(root): value matches 2 schemas instead of exactly one (schema /oneOf)"}},
+		{`30`, []string{"(root): value matches the schema it must not match (schema /not)"}},
+		{`"a"`, []string{
+			"(root): expected number got string (schema /allOf/0/type)",
+			"(root): value matches 2 schemas instead of exactly one (schema /oneOf)",
+		}},
+	}
+
+	for _, test := range tests {
+		err := schema.Validate(parse(t, test.instance))
+		if test.expected == nil {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", test.instance, err)
+			}
+			continue
+		}
+		assertErrors(t, err, test.expected)
+	}
+}
+
+func TestNumberModes(t *testing.T) {
+	document, err := JSONParser.ParseWithOptions([]byte(`{"type": "integer", "maximum": 12345678901234567890, "multipleOf": 10}`), JSONParser.Options{Numbers: JSONScanner.NumbersAsLiteral})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := Compile(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := []byte(`12345678901234567890`)
+	for _, mode := range []JSONScanner.NumberMode{JSONScanner.NumbersAsLiteral, JSONScanner.NumbersAsInt64} {
+		instance, err := JSONParser.ParseWithOptions(input, JSONParser.Options{Numbers: mode})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := schema.Validate(instance); err != nil {
+			t.Errorf("mode %d: unexpected error %v", mode, err)
+		}
+	}
+
+	instance, _ := JSONParser.ParseWithOptions([]byte(`12345678901234567891`), JSONParser.Options{Numbers: JSONScanner.NumbersAsLiteral})
+	assertErrors(t, schema.Validate(instance), []string{
+		"(root): 12345678901234567891 is greater than the maximum 12345678901234567890 (schema /maximum)",
+		"(root): 12345678901234567891 is not a multiple of 10 (schema /multipleOf)",
+	})
+}
+
+func TestValidatePosts(t *testing.T) {
+	input, err := os.ReadFile("../tests/big/posts.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := compile(t, `{
+		"type": "array",
+		"items": {
+			"type": "object",
+			"properties": {
+				"userId": {"type": "integer", "minimum": 1},
+				"id": {"type": "integer"},
+				"title": {"type": "string", "minLength": 1},
+				"body": {"type": "string"}
+			},
+			"required": ["userId", "id", "title", "body"],
+			"additionalProperties": false
+		}
+	}`)
+
+	if err := schema.Validate(parse(t, string(input))); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
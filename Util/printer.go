@@ -0,0 +1,138 @@
+package Util
+
+import (
+	"JSONParser/JSONParser"
+	"io"
+	"sort"
+)
+
+// PrintOptions configures a Printer, the zero value indents with two spaces
+type PrintOptions struct {
+	// Indent is written once per nesting level, two spaces when empty
+	Indent string
+	// Prefix starts every line, like the - and + of a diff
+	Prefix string
+	// Compact writes the value on a single line without spaces, Indent is ignored
+	Compact bool
+	// SortKeys writes the members of an *JSONParser.OrderedObject sorted by key,
+	// the keys of a map are always sorted so the output is stable
+	SortKeys bool
+}
+
+// Printer writes the values returned by JSONParser.Parse as indented json
+type Printer struct {
+	w       io.Writer
+	options PrintOptions
+}
+
+func NewPrinter(w io.Writer, options PrintOptions) *Printer {
+	if options.Indent == "" {
+		options.Indent = "  "
+	}
+	return &Printer{w: w, options: options}
+}
+
+// Print writes the value and a newline with a single call to Write,
+// nothing is written when the value holds a type or a number json can't represent.
+func (p *Printer) Print(value interface{}) error {
+	buf, err := p.appendValue([]byte(p.options.Prefix), value, 0)
+	if err != nil {
+		return err
+	}
+	buf = append(buf, '\n')
+	_, err = p.w.Write(buf)
+	return err
+}
+
+func (p *Printer) appendValue(buf []byte, value interface{}, level int) ([]byte, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return p.appendMembers(buf, keys, func(k string) interface{} {
+			return v[k]
+		}, level)
+	case *JSONParser.OrderedObject:
+		keys := v.Keys()
+		if p.options.SortKeys {
+			keys = append([]string{}, keys...)
+			sort.Strings(keys)
+		}
+		return p.appendMembers(buf, keys, func(k string) interface{} {
+			member, _ := v.Get(k)
+			return member
+		}, level)
+	case []interface{}:
+		return p.appendArray(buf, v, level)
+	default:
+		// the scalars are written by Stringify so the strings are escaped and the numbers formatted like json
+		text, err := JSONParser.Stringify(value)
+		if err != nil {
+			return nil, err
+		}
+		return append(buf, text...), nil
+	}
+}
+
+func (p *Printer) appendMembers(buf []byte, keys []string, get func(string) interface{}, level int) ([]byte, error) {
+	if len(keys) == 0 {
+		return append(buf, "{}"...), nil
+	}
+
+	var err error
+	buf = append(buf, '{')
+	for i, k := range keys {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = p.newline(buf, level+1)
+		if buf, err = p.appendValue(buf, k, level+1); err != nil {
+			return nil, err
+		}
+		buf = append(buf, ':')
+		if !p.options.Compact {
+			buf = append(buf, ' ')
+		}
+		if buf, err = p.appendValue(buf, get(k), level+1); err != nil {
+			return nil, err
+		}
+	}
+	buf = p.newline(buf, level)
+	return append(buf, '}'), nil
+}
+
+func (p *Printer) appendArray(buf []byte, array []interface{}, level int) ([]byte, error) {
+	if len(array) == 0 {
+		return append(buf, "[]"...), nil
+	}
+
+	var err error
+	buf = append(buf, '[')
+	for i, element := range array {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = p.newline(buf, level+1)
+		if buf, err = p.appendValue(buf, element, level+1); err != nil {
+			return nil, err
+		}
+	}
+	buf = p.newline(buf, level)
+	return append(buf, ']'), nil
+}
+
+// newline starts a new line indented for the nesting level, nothing in compact mode
+func (p *Printer) newline(buf []byte, level int) []byte {
+	if p.options.Compact {
+		return buf
+	}
+	buf = append(buf, '\n')
+	buf = append(buf, p.options.Prefix...)
+	for i := 0; i < level; i++ {
+		buf = append(buf, p.options.Indent...)
+	}
+	return buf
+}
@@ -0,0 +1,109 @@
+package Util
+
+import (
+	"JSONParser/JSONParser"
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPrinterMatchesNativeLib(t *testing.T) {
+	cases := []string{"../tests/step4/valid2.json",
+		"../tests/big/posts.json",
+		"../tests/big/bitcoin.json"}
+
+	for _, filename := range cases {
+		t.Run(filename, func(t *testing.T) {
+			input, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := JSONParser.Parse(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var native interface{}
+			if err := json.Unmarshal(input, &native); err != nil {
+				t.Fatal(err)
+			}
+
+			indented, err := json.MarshalIndent(native, "> ", "\t")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var output strings.Builder
+			if err := NewPrinter(&output, PrintOptions{Indent: "\t", Prefix: "> "}).Print(parsed); err != nil {
+				t.Fatal(err)
+			}
+			if expected := "> " + string(indented) + "\n"; output.String() != expected {
+				t.Errorf("expected\n%s\ngot\n%s", expected, output.String())
+			}
+
+			compact, err := json.Marshal(native)
+			if err != nil {
+				t.Fatal(err)
+			}
+			output.Reset()
+			if err := NewPrinter(&output, PrintOptions{Compact: true}).Print(parsed); err != nil {
+				t.Fatal(err)
+			}
+			if expected := string(compact) + "\n"; output.String() != expected {
+				t.Errorf("expected\n%s\ngot\n%s", expected, output.String())
+			}
+		})
+	}
+}
+
+func TestPrinterOptions(t *testing.T) {
+	parsed, err := JSONParser.ParseWithOptions([]byte(`{"b": [], "a": {"z": "tab\tquote\" \u0001 é", "y": {}}}`), JSONParser.Options{OrderedObjects: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		options  PrintOptions
+		expected string
+	}{
+		{PrintOptions{}, "{\n  \"b\": [],\n  \"a\": {\n    \"z\": \"tab\\tquote\\\" \\u0001 é\",\n    \"y\": {}\n  }\n}\n"},
+		{PrintOptions{SortKeys: true, Indent: " "}, "{\n \"a\": {\n  \"y\": {},\n  \"z\": \"tab\\tquote\\\" \\u0001 é\"\n },\n \"b\": []\n}\n"},
+		{PrintOptions{Compact: true, SortKeys: true, Prefix: "+"}, "+{\"a\":{\"y\":{},\"z\":\"tab\\tquote\\\" \\u0001 é\"},\"b\":[]}\n"},
+	}
+
+	for _, c := range cases {
+		var output strings.Builder
+		if err := NewPrinter(&output, c.options).Print(parsed); err != nil {
+			t.Fatal(err)
+		}
+		if output.String() != c.expected {
+			t.Errorf("%+v: expected\n%s\ngot\n%s", c.options, c.expected, output.String())
+		}
+	}
+}
+
+func TestPrinterErrors(t *testing.T) {
+	for _, value := range []interface{}{math.NaN(), []interface{}{1}, map[string]interface{}{"a": struct{}{}}} {
+		var output strings.Builder
+		if err := NewPrinter(&output, PrintOptions{}).Print(value); err == nil {
+			t.Errorf("%v: expected an error", value)
+		}
+		if output.Len() > 0 {
+			t.Errorf("%v: expected nothing written got %q", value, output.String())
+		}
+	}
+
+	writeErr := errors.New("closed pipe")
+	if err := NewPrinter(errWriter{writeErr}, PrintOptions{}).Print("value"); !errors.Is(err, writeErr) {
+		t.Errorf("expected %v got %v", writeErr, err)
+	}
+}
+
+type errWriter struct {
+	err error
+}
+
+func (w errWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
@@ -2,22 +2,69 @@ package main
 
 import (
 	"JSONParser/JSONParser"
+	"JSONParser/JSONPath"
+	"JSONParser/JSONSchema"
 	"JSONParser/Util"
+	"flag"
 	"log"
 	"os"
 )
 
 func main() {
-	input, err := os.ReadFile("tests/step4/valid2.json")
+	query := flag.String("query", "", "print the values selected by a JSONPath query like $..[?(@.albumId==2)].url")
+	infer := flag.Bool("infer", false, "print a JSON Schema inferred from the files, every file is a sample")
+	records := flag.Bool("records", false, "with -infer, the elements of a file holding an array are the samples, not the file")
+	flag.Parse()
 
-	if err != nil {
+	files := flag.Args()
+	if len(files) == 0 {
+		files = []string{"tests/step4/valid2.json"}
+	}
+
+	if *infer {
+		var samples []interface{}
+		for _, file := range files {
+			parsed := parseFile(file)
+			if elements, isArray := parsed.([]interface{}); *records && isArray {
+				samples = append(samples, elements...)
+			} else {
+				samples = append(samples, parsed)
+			}
+		}
+		printValue(JSONSchema.Infer(samples, JSONSchema.InferOptions{MaxEnum: 10}))
+		return
+	}
+
+	parsed := parseFile(files[0])
+
+	if *query != "" {
+		values, err := JSONPath.Query(*query, parsed)
+		if err != nil {
+			log.Fatal(err)
+		}
+		printValue(values)
+		return
+	}
+
+	printValue(parsed)
+}
+
+func printValue(value interface{}) {
+	if err := Util.Printify(value); err != nil {
 		log.Fatal(err)
 	}
+}
+
+func parseFile(file string) interface{} {
+	input, err := os.ReadFile(file)
 
-	parsed, err := JSONParser.Parse(input)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	Util.Printify(parsed)
+	parsed, err := JSONParser.ParseWithOptions(input, JSONParser.Options{OrderedObjects: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+	return parsed
 }